@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"golang.org/x/tools/imports"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// header is the beginning of every generated file.
+const header = `{{define "header" -}}
+// Code generated by godsgen -template {{.Template}} -type {{.Type}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{printf "%q" .}}
+{{- end}}
+)
+{{- end}}`
+
+// suffixes are the default suffixes of generated type names by template.
+var suffixes = map[string]string{
+	"dll":   "List",
+	"stack": "Stack",
+	"deque": "Deque",
+	"set":   "Set",
+}
+
+// Config describes the code to generate.
+type Config struct {
+	// Template is one of dll, stack, deque and set.
+	Template string
+	// Type is the element type as written in the generated package, for example int or
+	// time.Duration.
+	Type string
+	// Imports are import paths the element type needs. They are only required when the
+	// package a qualified type refers to cannot be found automatically, for example when two
+	// packages share its name.
+	Imports []string
+	// Package is the name of the generated package.
+	Package string
+	// Name is the name of the generated type. It defaults to the capitalized element type
+	// followed by the kind of collection, for example IntList.
+	Name string
+}
+
+var (
+	// ErrUnknownTemplate is returned when the template is not one of Templates.
+	ErrUnknownTemplate = errors.New("godsgen: unknown template")
+	// ErrInvalidConfig is returned when a type, package, name or import path is not valid.
+	ErrInvalidConfig = errors.New("godsgen: invalid configuration")
+)
+
+// Templates returns the names of the available templates in order.
+func Templates() []string {
+	names := make([]string, 0, len(suffixes))
+	for name := range suffixes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// defaultName derives a type name from the element type, or returns "" if it has no
+// identifier to derive it from.
+func defaultName(typ, suffix string) string {
+	typ = typ[strings.LastIndex(typ, ".")+1:]
+	if !token.IsIdentifier(typ) {
+		return ""
+	}
+	runes := []rune(typ)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes) + suffix
+}
+
+// validate checks the configuration and fills in the default name.
+func (c *Config) validate() error {
+	suffix, ok := suffixes[c.Template]
+	if !ok {
+		return fmt.Errorf("%w %q, expected one of %s", ErrUnknownTemplate, c.Template, strings.Join(Templates(), ", "))
+	}
+	if _, err := parser.ParseExpr(c.Type); err != nil || strings.TrimSpace(c.Type) == "" {
+		return fmt.Errorf("%w: element type %q is not a type expression", ErrInvalidConfig, c.Type)
+	}
+	if !token.IsIdentifier(c.Package) {
+		return fmt.Errorf("%w: package name %q is not an identifier", ErrInvalidConfig, c.Package)
+	}
+	if c.Name == "" {
+		c.Name = defaultName(c.Type, suffix)
+		if c.Name == "" {
+			return fmt.Errorf("%w: cannot derive a type name from %q, set one", ErrInvalidConfig, c.Type)
+		}
+	}
+	if !token.IsIdentifier(c.Name) {
+		return fmt.Errorf("%w: type name %q is not an identifier", ErrInvalidConfig, c.Name)
+	}
+	for _, imp := range c.Imports {
+		if imp == "" || strings.ContainsAny(imp, " \"\\") || path.Clean(imp) != imp {
+			return fmt.Errorf("%w: import path %q is not valid", ErrInvalidConfig, imp)
+		}
+	}
+	return nil
+}
+
+// Generate returns the formatted source of a non-generic collection described by config.
+// Missing imports, such as those of a qualified element type, are added the way goimports
+// adds them.
+func Generate(config Config) ([]byte, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	config.Imports = dedupImports(config.Imports)
+	tmpl, err := template.New("header").Parse(header)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl, err = tmpl.ParseFS(templateFS, "templates/"+config.Template+".tmpl"); err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&b, config.Template+".tmpl", config); err != nil {
+		return nil, err
+	}
+	src, err := imports.Process("", b.Bytes(), &imports.Options{Comments: true, TabIndent: true, TabWidth: 8})
+	if err != nil {
+		return nil, fmt.Errorf("%w: generated code does not parse: %v", ErrInvalidConfig, err)
+	}
+	return src, nil
+}
+
+// dedupImports returns the sorted import paths without duplicates.
+func dedupImports(paths []string) []string {
+	paths = slices.Clone(paths)
+	slices.Sort(paths)
+	return slices.Compact(paths)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// typeCheck parses and type-checks generated files as one package.
+func typeCheck(t *testing.T, sources ...[]byte) *types.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for i, src := range sources {
+		file, err := parser.ParseFile(fset, filepath.Join("gen", string(rune('a'+i))+".go"), src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("Generated code does not parse: %v\n%s", err, src)
+		}
+		files = append(files, file)
+	}
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := config.Check("gen", fset, files, nil)
+	if err != nil {
+		t.Fatalf("Generated code does not type-check: %v", err)
+	}
+	return pkg
+}
+
+func TestGenerate(t *testing.T) {
+	methods := map[string][]string{
+		"dll": {"Append", "Prepend", "Insert", "Get", "Set", "Delete", "Pop", "PopFirst", "Length", "IsEmpty", "Range", "Clear",
+			"AppendNode", "PrependNode", "PopNode", "PopFirstNode", "GetNode", "DeleteNode"},
+		"stack": {"Push", "Pop", "Peek", "IsEmpty", "Length", "Clear"},
+		"deque": {"Append", "Prepend", "Pop", "PopFirst", "IsEmpty", "Length", "Clear"},
+		"set":   {"Add", "Remove", "Contains", "Values", "Range", "IsEmpty", "Length", "Clear"},
+	}
+	tests := []struct {
+		config Config
+		name   string
+	}{
+		{Config{Template: "dll", Type: "int", Package: "gen"}, "IntList"},
+		{Config{Template: "stack", Type: "string", Package: "gen"}, "StringStack"},
+		{Config{Template: "deque", Type: "time.Duration", Package: "gen"}, "DurationDeque"},
+		{Config{Template: "stack", Type: "url.URL", Imports: []string{"net/url", "net/url"}, Package: "gen"}, "URLStack"},
+		{Config{Template: "set", Type: "[2]uint8", Package: "gen", Name: "PairSet"}, "PairSet"},
+	}
+	var sources [][]byte
+	for _, test := range tests {
+		src, err := Generate(test.config)
+		if err != nil {
+			t.Fatalf("Generate(%+v): %v", test.config, err)
+		}
+		if !bytes.HasPrefix(src, []byte("// Code generated by godsgen")) || !bytes.Contains(src, []byte("DO NOT EDIT.")) {
+			t.Errorf("Expected a generated code header, got\n%s", src[:80])
+		}
+		pkg := typeCheck(t, src)
+		obj := pkg.Scope().Lookup(test.name)
+		if obj == nil {
+			t.Fatalf("Expected type %s in the generated code", test.name)
+		}
+		methodSet := types.NewMethodSet(types.NewPointer(obj.Type()))
+		for _, method := range methods[test.config.Template] {
+			if methodSet.Lookup(pkg, method) == nil {
+				t.Errorf("Expected %s to have method %s", test.name, method)
+			}
+		}
+		sources = append(sources, src)
+	}
+	// Types generated from every template can live in the same package.
+	typeCheck(t, sources...)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		config Config
+		err    error
+	}{
+		{Config{Template: "tree", Type: "int", Package: "gen"}, ErrUnknownTemplate},
+		{Config{Template: "dll", Type: "", Package: "gen"}, ErrInvalidConfig},
+		{Config{Template: "dll", Type: "int)", Package: "gen"}, ErrInvalidConfig},
+		{Config{Template: "dll", Type: "int", Package: "my-pkg"}, ErrInvalidConfig},
+		{Config{Template: "dll", Type: "[]byte", Package: "gen"}, ErrInvalidConfig},
+		{Config{Template: "dll", Type: "int", Package: "gen", Name: "Int List"}, ErrInvalidConfig},
+		{Config{Template: "dll", Type: "int", Package: "gen", Imports: []string{"a b"}}, ErrInvalidConfig},
+	}
+	for _, test := range tests {
+		if _, err := Generate(test.config); !errors.Is(err, test.err) {
+			t.Errorf("Generate(%+v): expected %v, got %v", test.config, test.err, err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"-template", "stack", "-type", "float64", "-package", "gen"}, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "type Float64Stack struct") {
+		t.Errorf("Expected a Float64Stack on stdout, got\n%s", stdout.String())
+	}
+
+	output := filepath.Join(t.TempDir(), "list.go")
+	if err := run([]string{"-template", "dll", "-type", "int", "-package", "gen", "-o", output}, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if src, err := os.ReadFile(output); err != nil || !bytes.Contains(src, []byte("type IntList struct")) {
+		t.Errorf("Expected an IntList in %s, got %v", output, err)
+	}
+
+	if err := run([]string{"-type", "int", "-package", "gen"}, &stdout, &stderr); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Expected a missing template to fail, got %v", err)
+	}
+	if err := run([]string{"-template", "dll", "-type", "int", "-package", "gen", "extra"}, &stdout, &stderr); err == nil {
+		t.Error("Expected extra arguments to fail")
+	}
+}
+
+// generatedTest exercises the generated types in a module of their own.
+const generatedTest = `package gen
+
+import "testing"
+
+func TestGenerated(t *testing.T) {
+	var l IntList
+	l.Append(2)
+	l.Prepend(0)
+	l.Insert(1, 1)
+	l.Set(2, 3)
+	node := NewIntListNode(4)
+	l.AppendNode(node)
+	var values []int
+	for v := range l.Range() {
+		values = append(values, v)
+	}
+	if len(values) != 4 || values[0] != 0 || values[1] != 1 || values[2] != 3 || values[3] != 4 {
+		t.Fatalf("unexpected list %v", values)
+	}
+	if !l.DeleteNode(node) || l.DeleteNode(node) || !l.Delete(0) || l.Length() != 2 {
+		t.Fatal("unexpected deletion")
+	}
+	if v, ok := l.PopFirst(); !ok || v != 1 {
+		t.Fatalf("unexpected PopFirst %d", v)
+	}
+
+	var s IntStack
+	s.Push(1)
+	s.Push(2)
+	if v, _ := s.Pop(); v != 2 {
+		t.Fatalf("unexpected Pop %d", v)
+	}
+	if v, _ := s.Peek(); v != 1 || s.Length() != 1 {
+		t.Fatalf("unexpected Peek %d", v)
+	}
+
+	var d IntDeque
+	for i := 0; i < 20; i++ {
+		d.Append(i)
+		d.Prepend(-i)
+	}
+	for i := 19; i >= 0; i-- {
+		if v, _ := d.Pop(); v != i {
+			t.Fatalf("unexpected Pop %d, expected %d", v, i)
+		}
+		if v, _ := d.PopFirst(); v != -i {
+			t.Fatalf("unexpected PopFirst %d, expected %d", v, -i)
+		}
+	}
+	if _, ok := d.Pop(); ok || !d.IsEmpty() {
+		t.Fatal("expected an empty deque")
+	}
+
+	var set IntSet
+	if !set.Add(1) || set.Add(1) || !set.Contains(1) || !set.Remove(1) || set.Remove(1) || !set.IsEmpty() {
+		t.Fatal("unexpected set operations")
+	}
+}
+`
+
+func TestGeneratedCode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping the build of generated code in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("The go command is not available")
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":      "module gen\n\ngo 1.18\n",
+		"gen_test.go": generatedTest,
+	}
+	for _, template := range Templates() {
+		src, err := Generate(Config{Template: template, Type: "int", Package: "gen"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[template+".go"] = string(src)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(goTool, "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Generated code does not pass its test: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,79 @@
+// Description: godsgen generates non-generic, type-specialized versions of the collections.
+//
+// Usage:
+//
+//	godsgen -template dll|stack|deque|set -type T [-package p] [-name Name] [-import path]... [-o file]
+//
+// For example, the directive
+//
+//	//go:generate godsgen -template dll -type int -package cache -o int_list.go
+//
+// writes an IntList with the same list and node methods as dll.DoublyLinkedList[int],
+// without the capacity and observer support, so performance-critical code avoids the
+// generic dictionary and toolchains without generics can use it. The dll template generates
+// a doubly linked list, stack a slice-backed stack, deque a ring-buffer deque and set a
+// map-backed set, whose element type must be comparable. The import of a qualified element
+// type such as time.Duration is added automatically, as goimports would; -import is only
+// needed when the package cannot be found that way.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// importPaths is a flag that can be repeated to collect import paths.
+type importPaths []string
+
+func (p *importPaths) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *importPaths) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// run parses the arguments and writes the generated code to the output file or to stdout.
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("godsgen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var config Config
+	var imports importPaths
+	flags.StringVar(&config.Template, "template", "", "collection to generate: "+strings.Join(Templates(), ", "))
+	flags.StringVar(&config.Type, "type", "", "element type, for example int or time.Duration")
+	flags.StringVar(&config.Package, "package", os.Getenv("GOPACKAGE"), "name of the generated package, defaults to $GOPACKAGE")
+	flags.StringVar(&config.Name, "name", "", "name of the generated type, defaults to the element type and the collection, for example IntList")
+	flags.Var(&imports, "import", "import path of the element type when it cannot be found automatically, may be repeated")
+	output := flags.String("o", "", "output file, defaults to stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", ErrInvalidConfig, flags.Args())
+	}
+	config.Imports = imports
+
+	src, err := Generate(config)
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*output, src, 0o644)
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,542 @@
+// Description: This package contains conformance tests for implementations of the collections interfaces.
+//
+// Each function runs behavioral, edge-case, randomized model-based and concurrency checks
+// against fresh collections of ints returned by a factory, for example:
+//
+//	func TestMyList(t *testing.T) {
+//		collectionstest.TestList(t, func() collections.List[int] { return mylist.New[int]() })
+//	}
+package collectionstest
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/mmygods/gods/ds/collections"
+	"github.com/mmygods/gods/ds/models/dll"
+)
+
+// Iterations is the number of random operations of a model-based check.
+var Iterations = 2000
+
+// Goroutines is the number of goroutines of a concurrency check, each running 100 operations.
+var Goroutines = 8
+
+// contents returns the elements of a list in order.
+func contents(list collections.List[int]) []int {
+	values := []int{}
+	for v := range list.Range() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// checkContents fails the test if the list does not hold exactly the expected elements.
+func checkContents(t *testing.T, list collections.List[int], expected []int) {
+	t.Helper()
+	if values := contents(list); !slices.Equal(values, expected) {
+		t.Fatalf("Expected elements %v, got %v", expected, values)
+	}
+	if list.Length() != len(expected) || list.IsEmpty() != (len(expected) == 0) {
+		t.Fatalf("Expected length %d, got %d (empty %v)", len(expected), list.Length(), list.IsEmpty())
+	}
+	for i, e := range expected {
+		if v, ok := list.Get(i); !ok || v != e {
+			t.Fatalf("Expected Get(%d) to return %d, got %d, %v", i, e, v, ok)
+		}
+	}
+}
+
+// TestList runs the conformance checks of collections.List against lists returned by newList,
+// which must return an empty list on every call.
+func TestList(t *testing.T, newList func() collections.List[int]) {
+	t.Run("Behavior", func(t *testing.T) {
+		list := newList()
+		checkContents(t, list, nil)
+		if !list.Append(2) || !list.Prepend(0) || !list.Insert(1, 1) || !list.Insert(3, 4) {
+			t.Fatal("Expected adding to an unbounded list to succeed")
+		}
+		checkContents(t, list, []int{0, 1, 2, 4})
+		if !list.Set(3, 3) {
+			t.Fatal("Expected Set in range to succeed")
+		}
+		checkContents(t, list, []int{0, 1, 2, 3})
+		if !list.Delete(1) {
+			t.Fatal("Expected Delete in range to succeed")
+		}
+		checkContents(t, list, []int{0, 2, 3})
+		if v, ok := list.Pop(); !ok || v != 3 {
+			t.Fatalf("Expected Pop to return 3, got %d, %v", v, ok)
+		}
+		if v, ok := list.PopFirst(); !ok || v != 0 {
+			t.Fatalf("Expected PopFirst to return 0, got %d, %v", v, ok)
+		}
+		checkContents(t, list, []int{2})
+	})
+
+	t.Run("EdgeCases", func(t *testing.T) {
+		list := newList()
+		if _, ok := list.Pop(); ok {
+			t.Error("Expected Pop on an empty list to fail")
+		}
+		if _, ok := list.PopFirst(); ok {
+			t.Error("Expected PopFirst on an empty list to fail")
+		}
+		for _, index := range []int{-1, 0, 1} {
+			if _, ok := list.Get(index); ok {
+				t.Errorf("Expected Get(%d) on an empty list to fail", index)
+			}
+			if list.Set(index, 9) {
+				t.Errorf("Expected Set(%d) on an empty list to fail", index)
+			}
+			if list.Delete(index) {
+				t.Errorf("Expected Delete(%d) on an empty list to fail", index)
+			}
+		}
+		if list.Insert(-1, 9) || list.Insert(1, 9) {
+			t.Error("Expected Insert out of range to fail")
+		}
+		if !list.Insert(0, 1) {
+			t.Fatal("Expected Insert at index 0 of an empty list to succeed")
+		}
+		if list.Insert(2, 9) || list.Set(1, 9) || list.Delete(1) {
+			t.Error("Expected operations past the end to fail")
+		}
+		if v, ok := list.Pop(); !ok || v != 1 {
+			t.Fatalf("Expected Pop of the single element to return 1, got %d, %v", v, ok)
+		}
+		checkContents(t, list, nil)
+		list.Append(5)
+		if v, ok := list.PopFirst(); !ok || v != 5 {
+			t.Fatalf("Expected PopFirst of the single element to return 5, got %d, %v", v, ok)
+		}
+		checkContents(t, list, nil)
+	})
+
+	t.Run("Model", func(t *testing.T) {
+		testListModel(t, newList())
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		list := newList()
+		testConcurrency(t, list.Append, list.Pop, list.Length)
+		var wg sync.WaitGroup
+		for g := 0; g < Goroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					list.Get(i % 10)
+					list.Set(i%10, i)
+					for range list.Range() {
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("Linearizability", func(t *testing.T) {
+		list := newList()
+		testLinearizability(t, func(r *Recorder, client int, rng *rand.Rand) {
+			l := r.List(client, list)
+			for i := 0; i < 6; i++ {
+				switch rng.Intn(6) {
+				case 0:
+					l.Append(client*10 + i)
+				case 1:
+					l.Insert(rng.Intn(3), client*10+i)
+				case 2:
+					l.Set(rng.Intn(3), client*10+i)
+				case 3:
+					l.Get(rng.Intn(3))
+				case 4:
+					l.Delete(rng.Intn(3))
+				case 5:
+					l.PopFirst()
+				}
+			}
+		})
+	})
+}
+
+// testLinearizability runs clients concurrently on recorded collections and checks that the
+// history is linearizable.
+func testLinearizability(t *testing.T, client func(r *Recorder, client int, rng *rand.Rand)) {
+	t.Helper()
+	r := NewRecorder()
+	var wg sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			client(r, c, rand.New(rand.NewSource(int64(c))))
+		}(c)
+	}
+	wg.Wait()
+	AssertLinearizable(t, r.History())
+}
+
+// testListModel runs random list operations and compares every result with a slice.
+func testListModel(t *testing.T, list collections.List[int]) {
+	rng := rand.New(rand.NewSource(1))
+	var model []int
+	for n := 0; n < Iterations; n++ {
+		index := rng.Intn(len(model)+3) - 1
+		data := rng.Int()
+		var got, expected string
+		switch op := rng.Intn(8); op {
+		case 0:
+			got = fmt.Sprint(list.Append(data))
+			model, expected = append(model, data), "true"
+		case 1:
+			got = fmt.Sprint(list.Prepend(data))
+			model, expected = slices.Insert(model, 0, data), "true"
+		case 2:
+			got = fmt.Sprint(list.Insert(index, data))
+			ok := index >= 0 && index <= len(model)
+			if ok {
+				model = slices.Insert(model, index, data)
+			}
+			expected = fmt.Sprint(ok)
+		case 3:
+			v, ok := list.Get(index)
+			got = fmt.Sprint(v, ok)
+			if ok = index >= 0 && index < len(model); ok {
+				expected = fmt.Sprint(model[index], ok)
+			} else {
+				expected = fmt.Sprint(0, ok)
+			}
+		case 4:
+			got = fmt.Sprint(list.Set(index, data))
+			ok := index >= 0 && index < len(model)
+			if ok {
+				model[index] = data
+			}
+			expected = fmt.Sprint(ok)
+		case 5:
+			got = fmt.Sprint(list.Delete(index))
+			ok := index >= 0 && index < len(model)
+			if ok {
+				model = slices.Delete(model, index, index+1)
+			}
+			expected = fmt.Sprint(ok)
+		case 6:
+			v, ok := list.Pop()
+			got = fmt.Sprint(v, ok)
+			if len(model) == 0 {
+				expected = fmt.Sprint(0, false)
+			} else {
+				expected = fmt.Sprint(model[len(model)-1], true)
+				model = model[:len(model)-1]
+			}
+		case 7:
+			v, ok := list.PopFirst()
+			got = fmt.Sprint(v, ok)
+			if len(model) == 0 {
+				expected = fmt.Sprint(0, false)
+			} else {
+				expected = fmt.Sprint(model[0], true)
+				model = model[1:]
+			}
+		}
+		if got != expected {
+			t.Fatalf("Operation %d: expected %s, got %s", n, expected, got)
+		}
+		if list.Length() != len(model) {
+			t.Fatalf("Operation %d: expected length %d, got %d", n, len(model), list.Length())
+		}
+		if n%50 == 0 {
+			checkContents(t, list, model)
+		}
+	}
+	checkContents(t, list, model)
+}
+
+// testConcurrency adds distinct elements and removes elements from several goroutines, then
+// checks that every added element was removed or is still held exactly once.
+func testConcurrency(t *testing.T, add func(int) bool, remove func() (int, bool), length func() int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	removed := make([][]int, Goroutines)
+	for g := 0; g < Goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if !add(g*100 + i) {
+					t.Errorf("Expected adding %d to succeed", g*100+i)
+				}
+				if i%2 == 1 {
+					if v, ok := remove(); ok {
+						removed[g] = append(removed[g], v)
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	var seen []int
+	for _, values := range removed {
+		seen = append(seen, values...)
+	}
+	for v, ok := remove(); ok; v, ok = remove() {
+		seen = append(seen, v)
+	}
+	if length() != 0 {
+		t.Errorf("Expected an empty collection after removing everything, got length %d", length())
+	}
+	slices.Sort(seen)
+	for i, v := range seen {
+		if v != i {
+			t.Fatalf("Expected every element exactly once, got %v", seen)
+		}
+	}
+	if len(seen) != Goroutines*100 {
+		t.Fatalf("Expected %d elements, got %d", Goroutines*100, len(seen))
+	}
+}
+
+// TestDeque runs the conformance checks of collections.Deque against deques returned by
+// newDeque, which must return an empty deque on every call.
+func TestDeque(t *testing.T, newDeque func() collections.Deque[int]) {
+	t.Run("Behavior", func(t *testing.T) {
+		deque := newDeque()
+		if !deque.Append(2) || !deque.Prepend(1) || !deque.Append(3) {
+			t.Fatal("Expected adding to an unbounded deque to succeed")
+		}
+		if deque.Length() != 3 || deque.IsEmpty() {
+			t.Fatalf("Expected length 3, got %d", deque.Length())
+		}
+		for _, e := range []int{3, 2} {
+			if v, ok := deque.Pop(); !ok || v != e {
+				t.Fatalf("Expected Pop to return %d, got %d, %v", e, v, ok)
+			}
+		}
+		if v, ok := deque.PopFirst(); !ok || v != 1 {
+			t.Fatalf("Expected PopFirst to return 1, got %d, %v", v, ok)
+		}
+	})
+
+	t.Run("EdgeCases", func(t *testing.T) {
+		deque := newDeque()
+		if _, ok := deque.Pop(); ok || !deque.IsEmpty() || deque.Length() != 0 {
+			t.Error("Expected Pop on an empty deque to fail")
+		}
+		if _, ok := deque.PopFirst(); ok {
+			t.Error("Expected PopFirst on an empty deque to fail")
+		}
+		deque.Prepend(7)
+		if v, ok := deque.Pop(); !ok || v != 7 || !deque.IsEmpty() {
+			t.Errorf("Expected the single element 7, got %d, %v", v, ok)
+		}
+	})
+
+	t.Run("Model", func(t *testing.T) {
+		deque := newDeque()
+		rng := rand.New(rand.NewSource(1))
+		var model []int
+		for n := 0; n < Iterations; n++ {
+			data := rng.Int()
+			switch rng.Intn(4) {
+			case 0:
+				deque.Append(data)
+				model = append(model, data)
+			case 1:
+				deque.Prepend(data)
+				model = slices.Insert(model, 0, data)
+			case 2:
+				v, ok := deque.Pop()
+				if ok != (len(model) > 0) || ok && v != model[len(model)-1] {
+					t.Fatalf("Operation %d: unexpected Pop result %d, %v", n, v, ok)
+				}
+				if ok {
+					model = model[:len(model)-1]
+				}
+			case 3:
+				v, ok := deque.PopFirst()
+				if ok != (len(model) > 0) || ok && v != model[0] {
+					t.Fatalf("Operation %d: unexpected PopFirst result %d, %v", n, v, ok)
+				}
+				if ok {
+					model = model[1:]
+				}
+			}
+			if deque.Length() != len(model) || deque.IsEmpty() != (len(model) == 0) {
+				t.Fatalf("Operation %d: expected length %d, got %d", n, len(model), deque.Length())
+			}
+		}
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		deque := newDeque()
+		testConcurrency(t, deque.Append, deque.PopFirst, deque.Length)
+		testConcurrency(t, deque.Prepend, deque.PopFirst, deque.Length)
+	})
+
+	t.Run("Linearizability", func(t *testing.T) {
+		deque := newDeque()
+		testLinearizability(t, func(r *Recorder, client int, rng *rand.Rand) {
+			d := r.Deque(client, deque)
+			for i := 0; i < 6; i++ {
+				switch rng.Intn(5) {
+				case 0:
+					d.Append(client*10 + i)
+				case 1:
+					d.Prepend(client*10 + i)
+				case 2:
+					d.Pop()
+				case 3:
+					d.PopFirst()
+				case 4:
+					d.Length()
+				}
+			}
+		})
+	})
+}
+
+// TestStack runs the conformance checks of collections.Stack against stacks returned by
+// newStack, which must return an empty stack on every call.
+func TestStack(t *testing.T, newStack func() collections.Stack[int]) {
+	t.Run("Behavior", func(t *testing.T) {
+		stack := newStack()
+		for i := 1; i <= 3; i++ {
+			stack.Push(i)
+			if v, ok := stack.Peek(); !ok || v != i {
+				t.Fatalf("Expected Peek to return %d, got %d, %v", i, v, ok)
+			}
+		}
+		for i := 3; i >= 1; i-- {
+			if v, ok := stack.Pop(); !ok || v != i {
+				t.Fatalf("Expected Pop to return %d, got %d, %v", i, v, ok)
+			}
+		}
+	})
+
+	t.Run("EdgeCases", func(t *testing.T) {
+		stack := newStack()
+		if _, ok := stack.Pop(); ok || !stack.IsEmpty() || stack.Length() != 0 {
+			t.Error("Expected Pop on an empty stack to fail")
+		}
+		if _, ok := stack.Peek(); ok {
+			t.Error("Expected Peek on an empty stack to fail")
+		}
+		stack.Push(0)
+		if v, ok := stack.Peek(); !ok || v != 0 || stack.Length() != 1 {
+			t.Error("Expected Peek not to remove the element")
+		}
+	})
+
+	t.Run("Model", func(t *testing.T) {
+		stack := newStack()
+		rng := rand.New(rand.NewSource(1))
+		var model []int
+		for n := 0; n < Iterations; n++ {
+			switch rng.Intn(3) {
+			case 0:
+				data := rng.Int()
+				stack.Push(data)
+				model = append(model, data)
+			case 1:
+				v, ok := stack.Pop()
+				if ok != (len(model) > 0) || ok && v != model[len(model)-1] {
+					t.Fatalf("Operation %d: unexpected Pop result %d, %v", n, v, ok)
+				}
+				if ok {
+					model = model[:len(model)-1]
+				}
+			case 2:
+				v, ok := stack.Peek()
+				if ok != (len(model) > 0) || ok && v != model[len(model)-1] {
+					t.Fatalf("Operation %d: unexpected Peek result %d, %v", n, v, ok)
+				}
+			}
+			if stack.Length() != len(model) || stack.IsEmpty() != (len(model) == 0) {
+				t.Fatalf("Operation %d: expected length %d, got %d", n, len(model), stack.Length())
+			}
+		}
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		stack := newStack()
+		push := func(data int) bool {
+			stack.Push(data)
+			return true
+		}
+		testConcurrency(t, push, stack.Pop, stack.Length)
+	})
+
+	t.Run("Linearizability", func(t *testing.T) {
+		stack := newStack()
+		testLinearizability(t, func(r *Recorder, client int, rng *rand.Rand) {
+			s := r.Stack(client, stack)
+			for i := 0; i < 6; i++ {
+				switch rng.Intn(3) {
+				case 0:
+					s.Push(client*10 + i)
+				case 1:
+					s.Pop()
+				case 2:
+					s.Peek()
+				}
+			}
+		})
+	})
+}
+
+// TestLruList runs the conformance checks of collections.LruList, including those of
+// collections.List, against lists returned by newList, which must return an empty list on
+// every call.
+func TestLruList(t *testing.T, newList func() collections.LruList[int, *dll.DllNode[int]]) {
+	TestList(t, func() collections.List[int] { return newList() })
+
+	t.Run("Nodes", func(t *testing.T) {
+		list := newList()
+		a, b, c := dll.NewNode(1), dll.NewNode(2), dll.NewNode(3)
+		if !list.AppendNode(b) || !list.PrependNode(a) || !list.AppendNode(c) {
+			t.Fatal("Expected adding nodes to succeed")
+		}
+		checkContents(t, list, []int{1, 2, 3})
+		if list.GetNode(1) != b || list.GetNode(3) != nil || list.GetNode(-1) != nil {
+			t.Fatal("Expected GetNode to return the node at the index or nil")
+		}
+		if !list.DeleteNode(b) || list.DeleteNode(b) || list.DeleteNode(nil) {
+			t.Fatal("Expected a node to be deleted once")
+		}
+		checkContents(t, list, []int{1, 3})
+		// Moving a node to the front is how an LRU cache records a use.
+		if !list.DeleteNode(c) || !list.PrependNode(c) {
+			t.Fatal("Expected a deleted node to be added again")
+		}
+		checkContents(t, list, []int{3, 1})
+		if node, ok := list.PopNode(); !ok || node != a || node.GetData() != 1 {
+			t.Fatal("Expected PopNode to return the last node")
+		}
+		if node, ok := list.PopFirstNode(); !ok || node != c || node.GetData() != 3 {
+			t.Fatal("Expected PopFirstNode to return the first node")
+		}
+		if _, ok := list.PopNode(); ok {
+			t.Error("Expected PopNode on an empty list to fail")
+		}
+		if _, ok := list.PopFirstNode(); ok {
+			t.Error("Expected PopFirstNode on an empty list to fail")
+		}
+	})
+
+	t.Run("NodesConcurrency", func(t *testing.T) {
+		list := newList()
+		add := func(data int) bool { return list.AppendNode(dll.NewNode(data)) }
+		remove := func() (int, bool) {
+			node, ok := list.PopFirstNode()
+			if !ok {
+				return 0, false
+			}
+			return node.GetData(), true
+		}
+		testConcurrency(t, add, remove, list.Length)
+	})
+}
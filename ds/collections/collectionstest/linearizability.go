@@ -0,0 +1,528 @@
+package collectionstest
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mmygods/gods/ds/collections"
+)
+
+// Result is the outcome of a recorded operation. Value holds returned elements and lengths,
+// OK returned booleans and Values the elements yielded by Range.
+type Result struct {
+	Value  int
+	OK     bool
+	Values []int
+}
+
+// Operation is a call recorded by a Recorder. Call and Return are logical timestamps taken
+// just before the call started and just after it returned.
+type Operation struct {
+	Client int
+	Method string
+	// Index is the index argument of Insert, Get, Set and Delete.
+	Index int
+	// Value is the element argument of Append, Prepend, Insert, Set and Push.
+	Value  int
+	Result Result
+	Call   int64
+	Return int64
+}
+
+// String returns the operation as a call with its result and timestamps.
+func (op Operation) String() string {
+	var args, result string
+	switch op.Method {
+	case "Append", "Prepend", "Push":
+		args = strconv.Itoa(op.Value)
+	case "Insert", "Set":
+		args = fmt.Sprintf("%d, %d", op.Index, op.Value)
+	case "Get", "Delete":
+		args = strconv.Itoa(op.Index)
+	}
+	switch op.Method {
+	case "Get", "Pop", "PopFirst", "Peek":
+		result = fmt.Sprintf("%d, %v", op.Result.Value, op.Result.OK)
+	case "Length":
+		result = strconv.Itoa(op.Result.Value)
+	case "Range":
+		result = fmt.Sprint(op.Result.Values)
+	case "Push":
+		result = "-"
+	default:
+		result = strconv.FormatBool(op.Result.OK)
+	}
+	return fmt.Sprintf("[%d, %d] client %d: %s(%s) -> %s", op.Call, op.Return, op.Client, op.Method, args, result)
+}
+
+// The Recorder struct records the operations made on collections from several goroutines.
+type Recorder struct {
+	clock   atomic.Int64
+	history []Operation
+	mu      sync.Mutex
+}
+
+// NewRecorder creates a new recorder with an empty history.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// record runs call as the operation op and adds it to the history.
+func (r *Recorder) record(op Operation, call func(result *Result)) {
+	op.Call = r.clock.Add(1)
+	call(&op.Result)
+	op.Return = r.clock.Add(1)
+	r.mu.Lock()
+	r.history = append(r.history, op)
+	r.mu.Unlock()
+}
+
+// History returns a copy of the recorded operations in a concurrency-safe manner.
+func (r *Recorder) History() []Operation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.history)
+}
+
+// List returns a list recording the operations the client makes on list. Each goroutine
+// should use its own client number.
+func (r *Recorder) List(client int, list collections.List[int]) collections.List[int] {
+	return &recordedList{recordedDeque{r: r, client: client, deque: list}, list}
+}
+
+// Deque returns a deque recording the operations the client makes on deque. Each goroutine
+// should use its own client number.
+func (r *Recorder) Deque(client int, deque collections.Deque[int]) collections.Deque[int] {
+	return &recordedDeque{r: r, client: client, deque: deque}
+}
+
+// Stack returns a stack recording the operations the client makes on stack. Each goroutine
+// should use its own client number.
+func (r *Recorder) Stack(client int, stack collections.Stack[int]) collections.Stack[int] {
+	return &recordedStack{r: r, client: client, stack: stack}
+}
+
+type recordedDeque struct {
+	r      *Recorder
+	client int
+	deque  collections.Deque[int]
+}
+
+func (d *recordedDeque) Append(data int) bool {
+	var ok bool
+	d.r.record(Operation{Client: d.client, Method: "Append", Value: data}, func(result *Result) {
+		ok = d.deque.Append(data)
+		result.OK = ok
+	})
+	return ok
+}
+
+func (d *recordedDeque) Prepend(data int) bool {
+	var ok bool
+	d.r.record(Operation{Client: d.client, Method: "Prepend", Value: data}, func(result *Result) {
+		ok = d.deque.Prepend(data)
+		result.OK = ok
+	})
+	return ok
+}
+
+func (d *recordedDeque) Pop() (int, bool) {
+	var v int
+	var ok bool
+	d.r.record(Operation{Client: d.client, Method: "Pop"}, func(result *Result) {
+		v, ok = d.deque.Pop()
+		result.Value, result.OK = v, ok
+	})
+	return v, ok
+}
+
+func (d *recordedDeque) PopFirst() (int, bool) {
+	var v int
+	var ok bool
+	d.r.record(Operation{Client: d.client, Method: "PopFirst"}, func(result *Result) {
+		v, ok = d.deque.PopFirst()
+		result.Value, result.OK = v, ok
+	})
+	return v, ok
+}
+
+func (d *recordedDeque) IsEmpty() bool {
+	var ok bool
+	d.r.record(Operation{Client: d.client, Method: "IsEmpty"}, func(result *Result) {
+		ok = d.deque.IsEmpty()
+		result.OK = ok
+	})
+	return ok
+}
+
+func (d *recordedDeque) Length() int {
+	var n int
+	d.r.record(Operation{Client: d.client, Method: "Length"}, func(result *Result) {
+		n = d.deque.Length()
+		result.Value = n
+	})
+	return n
+}
+
+type recordedList struct {
+	recordedDeque
+	list collections.List[int]
+}
+
+func (l *recordedList) Insert(index int, data int) bool {
+	var ok bool
+	l.r.record(Operation{Client: l.client, Method: "Insert", Index: index, Value: data}, func(result *Result) {
+		ok = l.list.Insert(index, data)
+		result.OK = ok
+	})
+	return ok
+}
+
+func (l *recordedList) Get(index int) (int, bool) {
+	var v int
+	var ok bool
+	l.r.record(Operation{Client: l.client, Method: "Get", Index: index}, func(result *Result) {
+		v, ok = l.list.Get(index)
+		result.Value, result.OK = v, ok
+	})
+	return v, ok
+}
+
+func (l *recordedList) Set(index int, data int) bool {
+	var ok bool
+	l.r.record(Operation{Client: l.client, Method: "Set", Index: index, Value: data}, func(result *Result) {
+		ok = l.list.Set(index, data)
+		result.OK = ok
+	})
+	return ok
+}
+
+func (l *recordedList) Delete(index int) bool {
+	var ok bool
+	l.r.record(Operation{Client: l.client, Method: "Delete", Index: index}, func(result *Result) {
+		ok = l.list.Delete(index)
+		result.OK = ok
+	})
+	return ok
+}
+
+// Range drains the range of the wrapped list inside the recorded call and replays it.
+func (l *recordedList) Range() <-chan int {
+	var values []int
+	l.r.record(Operation{Client: l.client, Method: "Range"}, func(result *Result) {
+		for v := range l.list.Range() {
+			values = append(values, v)
+		}
+		result.Values = values
+	})
+	ch := make(chan int, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+type recordedStack struct {
+	r      *Recorder
+	client int
+	stack  collections.Stack[int]
+}
+
+func (s *recordedStack) Push(data int) {
+	s.r.record(Operation{Client: s.client, Method: "Push", Value: data}, func(result *Result) {
+		s.stack.Push(data)
+		result.OK = true
+	})
+}
+
+func (s *recordedStack) Pop() (int, bool) {
+	var v int
+	var ok bool
+	s.r.record(Operation{Client: s.client, Method: "Pop"}, func(result *Result) {
+		v, ok = s.stack.Pop()
+		result.Value, result.OK = v, ok
+	})
+	return v, ok
+}
+
+func (s *recordedStack) Peek() (int, bool) {
+	var v int
+	var ok bool
+	s.r.record(Operation{Client: s.client, Method: "Peek"}, func(result *Result) {
+		v, ok = s.stack.Peek()
+		result.Value, result.OK = v, ok
+	})
+	return v, ok
+}
+
+func (s *recordedStack) IsEmpty() bool {
+	var ok bool
+	s.r.record(Operation{Client: s.client, Method: "IsEmpty"}, func(result *Result) {
+		ok = s.stack.IsEmpty()
+		result.OK = ok
+	})
+	return ok
+}
+
+func (s *recordedStack) Length() int {
+	var n int
+	s.r.record(Operation{Client: s.client, Method: "Length"}, func(result *Result) {
+		n = s.stack.Length()
+		result.Value = n
+	})
+	return n
+}
+
+// apply runs an operation on the sequence modelling a list, deque or stack whose last
+// element is the top of the stack and holds at most capacity elements, or any number if
+// capacity is 0. It returns the resulting sequence and whether the recorded result is what
+// the model returns. An addition may only return false when the sequence is full, in which
+// case it leaves the sequence unchanged as a bounded collection rejecting it does.
+func apply(state []int, op Operation, capacity int) ([]int, bool) {
+	n := len(state)
+	full := capacity > 0 && n >= capacity
+	inRange := op.Index >= 0 && op.Index < n
+	switch op.Method {
+	case "Append", "Push":
+		if full || !op.Result.OK {
+			return state, full && !op.Result.OK
+		}
+		return append(state[:n:n], op.Value), true
+	case "Prepend":
+		if full || !op.Result.OK {
+			return state, full && !op.Result.OK
+		}
+		return append([]int{op.Value}, state...), true
+	case "Insert":
+		if op.Index < 0 || op.Index > n {
+			return state, !op.Result.OK
+		}
+		if full || !op.Result.OK {
+			return state, full && !op.Result.OK
+		}
+		return slices.Insert(slices.Clone(state), op.Index, op.Value), true
+	case "Get":
+		if !inRange {
+			return state, !op.Result.OK
+		}
+		return state, op.Result.OK && op.Result.Value == state[op.Index]
+	case "Set":
+		if !inRange {
+			return state, !op.Result.OK
+		}
+		if !op.Result.OK {
+			return state, false
+		}
+		next := slices.Clone(state)
+		next[op.Index] = op.Value
+		return next, true
+	case "Delete":
+		if !inRange {
+			return state, !op.Result.OK
+		}
+		if !op.Result.OK {
+			return state, false
+		}
+		return slices.Delete(slices.Clone(state), op.Index, op.Index+1), true
+	case "Pop", "Peek":
+		if n == 0 {
+			return state, !op.Result.OK
+		}
+		if !op.Result.OK || op.Result.Value != state[n-1] {
+			return state, false
+		}
+		if op.Method == "Peek" {
+			return state, true
+		}
+		return state[: n-1 : n-1], true
+	case "PopFirst":
+		if n == 0 {
+			return state, !op.Result.OK
+		}
+		if !op.Result.OK || op.Result.Value != state[0] {
+			return state, false
+		}
+		return state[1:], true
+	case "Length":
+		return state, op.Result.Value == n
+	case "IsEmpty":
+		return state, op.Result.OK == (n == 0)
+	case "Range":
+		return state, slices.Equal(op.Result.Values, state)
+	default:
+		return state, false
+	}
+}
+
+// linearizable searches for an order of the operations respecting their real-time order in
+// which every result matches the sequential model, as Wing and Gong do, remembering the
+// explored pairs of linearized operations and model states to prune the search.
+func linearizable(history []Operation, capacity int) bool {
+	ops := slices.Clone(history)
+	sort.SliceStable(ops, func(i, j int) bool { return ops[i].Call < ops[j].Call })
+	done := make([]bool, len(ops))
+	explored := make(map[string]struct{})
+	var key strings.Builder
+
+	var search func(state []int, remaining int) bool
+	search = func(state []int, remaining int) bool {
+		if remaining == 0 {
+			return true
+		}
+		key.Reset()
+		for _, d := range done {
+			if d {
+				key.WriteByte('1')
+			} else {
+				key.WriteByte('0')
+			}
+		}
+		for _, v := range state {
+			key.WriteByte(',')
+			key.WriteString(strconv.Itoa(v))
+		}
+		if _, ok := explored[key.String()]; ok {
+			return false
+		}
+		explored[key.String()] = struct{}{}
+
+		// An operation can take effect next if no other pending operation returned before it
+		// was called.
+		firstReturn := int64(math.MaxInt64)
+		for i, op := range ops {
+			if !done[i] {
+				firstReturn = min(firstReturn, op.Return)
+			}
+		}
+		for i, op := range ops {
+			if done[i] || op.Call > firstReturn {
+				continue
+			}
+			if next, ok := apply(state, op, capacity); ok {
+				done[i] = true
+				if search(next, remaining-1) {
+					return true
+				}
+				done[i] = false
+			}
+		}
+		return false
+	}
+	return search(nil, len(ops))
+}
+
+// explained reports whether every element returned by an operation of the history was added
+// by an operation of the history.
+func explained(history []Operation) bool {
+	added := make(map[int]bool)
+	for _, op := range history {
+		switch op.Method {
+		case "Append", "Prepend", "Insert", "Set", "Push":
+			if op.Result.OK {
+				added[op.Value] = true
+			}
+		}
+	}
+	for _, op := range history {
+		switch op.Method {
+		case "Get", "Pop", "PopFirst", "Peek":
+			if op.Result.OK && !added[op.Result.Value] {
+				return false
+			}
+		case "Range":
+			for _, v := range op.Result.Values {
+				if !added[v] {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// CheckLinearizability checks that a history recorded on an unbounded collection that was
+// initially empty is linearizable. It returns nil if it is, and otherwise a sub-history that
+// is not linearizable and from which no operation can be removed without it becoming
+// linearizable or, if the history only returns elements it added, returning an element that
+// none of its operations added. The search takes exponential time in the worst case, so
+// histories should be kept to a few hundred operations.
+func CheckLinearizability(history []Operation) []Operation {
+	return checkLinearizability(history, 0)
+}
+
+// CheckLinearizabilityWithCapacity is CheckLinearizability for a collection holding at most
+// capacity elements that rejects additions when it is full, returning false from them.
+// It panics if capacity is less than 1.
+func CheckLinearizabilityWithCapacity(history []Operation, capacity int) []Operation {
+	if capacity < 1 {
+		panic("collectionstest: capacity must be at least 1")
+	}
+	return checkLinearizability(history, capacity)
+}
+
+// checkLinearizability checks a history against a model of the capacity and shrinks it if
+// it is not linearizable.
+func checkLinearizability(history []Operation, capacity int) []Operation {
+	if linearizable(history, capacity) {
+		return nil
+	}
+	ops := slices.Clone(history)
+	sort.SliceStable(ops, func(i, j int) bool { return ops[i].Call < ops[j].Call })
+	// Remove ever smaller chunks of operations while the rest is still not linearizable.
+	// Keeping the additions of returned elements keeps the sub-history meaningful, unless
+	// the history returns elements it never added.
+	// Removing an operation can make another one removable, so single operations are removed
+	// until none can be.
+	keepAdditions := explained(ops)
+	for chunk := max(len(ops)/2, 1); ; chunk = max(chunk/2, 1) {
+		removed := false
+		for start := 0; start < len(ops); {
+			end := min(start+chunk, len(ops))
+			candidate := append(ops[:start:start], ops[end:]...)
+			if len(candidate) > 0 && (!keepAdditions || explained(candidate)) && !linearizable(candidate, capacity) {
+				ops = candidate
+				removed = true
+			} else {
+				start = end
+			}
+		}
+		if chunk == 1 && !removed {
+			return ops
+		}
+	}
+}
+
+// AssertLinearizable fails the test and reports a minimal non-linearizable sub-history if
+// history, recorded on an unbounded collection, is not linearizable.
+func AssertLinearizable(t testing.TB, history []Operation) {
+	t.Helper()
+	report(t, history, CheckLinearizability(history))
+}
+
+// AssertLinearizableWithCapacity is AssertLinearizable for a collection holding at most
+// capacity elements that rejects additions when it is full.
+func AssertLinearizableWithCapacity(t testing.TB, history []Operation, capacity int) {
+	t.Helper()
+	report(t, history, CheckLinearizabilityWithCapacity(history, capacity))
+}
+
+// report fails the test with the sub-history ops if it is not nil.
+func report(t testing.TB, history, ops []Operation) {
+	t.Helper()
+	if ops != nil {
+		var b strings.Builder
+		for _, op := range ops {
+			b.WriteString("\n\t")
+			b.WriteString(op.String())
+		}
+		t.Fatalf("History of %d operations is not linearizable, minimal sub-history:%s", len(history), b.String())
+	}
+}
@@ -0,0 +1,226 @@
+package collectionstest_test
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/mmygods/gods/ds/collections/collectionstest"
+	"github.com/mmygods/gods/ds/models/dll"
+)
+
+// op builds an operation called at call and returned at ret.
+func op(client int, method string, value int, result collectionstest.Result, call, ret int64) collectionstest.Operation {
+	return collectionstest.Operation{Client: client, Method: method, Value: value, Result: result, Call: call, Return: ret}
+}
+
+// returnsAdded reports whether every element returned by an operation was added by one.
+func returnsAdded(ops []collectionstest.Operation) bool {
+	added := make(map[int]bool)
+	for _, op := range ops {
+		switch op.Method {
+		case "Append", "Prepend", "Insert", "Set", "Push":
+			added[op.Value] = added[op.Value] || op.Result.OK
+		}
+	}
+	for _, op := range ops {
+		switch op.Method {
+		case "Get", "Pop", "PopFirst", "Peek":
+			if op.Result.OK && !added[op.Result.Value] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkMinimal fails the test unless ops is a non-linearizable sub-history of history from
+// which removing any operation makes it linearizable or drops an addition it needs.
+func checkMinimal(t *testing.T, check func([]collectionstest.Operation) []collectionstest.Operation,
+	history, ops []collectionstest.Operation) {
+	t.Helper()
+	if check(ops) == nil {
+		t.Fatalf("Expected the sub-history %v not to be linearizable", ops)
+	}
+	for i := range ops {
+		rest := slices.Delete(slices.Clone(ops), i, i+1)
+		if len(rest) > 0 && (!returnsAdded(history) || returnsAdded(rest)) && check(rest) != nil {
+			t.Fatalf("Expected the sub-history %v of %v to be minimal, %v is not linearizable either", ops, history, rest)
+		}
+	}
+}
+
+func TestCheckLinearizability(t *testing.T) {
+	ok := collectionstest.Result{OK: true}
+	tests := []struct {
+		name     string
+		history  []collectionstest.Operation
+		capacity int
+		minimal  int
+	}{
+		{
+			name: "Sequential",
+			history: []collectionstest.Operation{
+				op(0, "Append", 1, ok, 1, 2),
+				op(0, "Pop", 0, collectionstest.Result{Value: 1, OK: true}, 3, 4),
+			},
+		},
+		{
+			name: "Overlapping pop takes effect after append",
+			history: []collectionstest.Operation{
+				op(0, "Append", 1, ok, 1, 4),
+				op(1, "Pop", 0, collectionstest.Result{Value: 1, OK: true}, 2, 3),
+			},
+		},
+		{
+			name: "Overlapping pop takes effect before append",
+			history: []collectionstest.Operation{
+				op(0, "Append", 1, ok, 1, 4),
+				op(1, "Pop", 0, collectionstest.Result{}, 2, 3),
+				op(1, "Length", 0, collectionstest.Result{Value: 1}, 5, 6),
+			},
+		},
+		{
+			name: "Pop of an empty deque after appends",
+			history: []collectionstest.Operation{
+				op(0, "Append", 1, ok, 1, 2),
+				op(1, "Prepend", 2, ok, 3, 6),
+				op(2, "Append", 3, ok, 4, 5),
+				op(1, "Pop", 0, collectionstest.Result{}, 7, 8),
+			},
+			minimal: 2,
+		},
+		{
+			name: "Element popped twice",
+			history: []collectionstest.Operation{
+				op(0, "Push", 5, ok, 1, 2),
+				op(0, "Push", 6, ok, 3, 4),
+				op(1, "Pop", 0, collectionstest.Result{Value: 6, OK: true}, 5, 8),
+				op(2, "Pop", 0, collectionstest.Result{Value: 6, OK: true}, 6, 7),
+				op(2, "Pop", 0, collectionstest.Result{Value: 5, OK: true}, 9, 10),
+			},
+			minimal: 3,
+		},
+		{
+			name: "Length before the append it counts",
+			history: []collectionstest.Operation{
+				op(0, "Length", 0, collectionstest.Result{Value: 3}, 0, 1),
+				op(1, "Prepend", 3, ok, 2, 4),
+				{Client: 2, Method: "Get", Index: 0, Result: collectionstest.Result{Value: 3, OK: true}, Call: 4, Return: 7},
+			},
+			minimal: 1,
+		},
+		{
+			name: "Insert out of range fails",
+			history: []collectionstest.Operation{
+				op(0, "Append", 1, ok, 1, 2),
+				{Client: 1, Method: "Insert", Index: 2, Value: 2, Call: 3, Return: 4},
+			},
+		},
+		{
+			name: "Insert in range fails",
+			history: []collectionstest.Operation{
+				op(0, "Append", 1, ok, 1, 2),
+				{Client: 1, Method: "Insert", Index: 1, Value: 2, Call: 3, Return: 4},
+			},
+			minimal: 2,
+		},
+		{
+			name: "Append fails without capacity",
+			history: []collectionstest.Operation{
+				op(0, "Append", 1, collectionstest.Result{}, 1, 2),
+			},
+			minimal: 1,
+		},
+		{
+			name: "Additions rejected when full",
+			history: []collectionstest.Operation{
+				op(0, "Append", 1, ok, 1, 2),
+				op(1, "Prepend", 2, collectionstest.Result{}, 3, 4),
+				{Client: 1, Method: "Insert", Index: 0, Value: 3, Call: 5, Return: 6},
+				op(0, "Pop", 0, collectionstest.Result{Value: 1, OK: true}, 7, 8),
+			},
+			capacity: 1,
+		},
+		{
+			name: "Addition rejected below capacity",
+			history: []collectionstest.Operation{
+				op(0, "Append", 1, ok, 1, 2),
+				op(1, "Append", 2, collectionstest.Result{}, 3, 4),
+			},
+			capacity: 2,
+			minimal:  1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := collectionstest.CheckLinearizability
+			if test.capacity > 0 {
+				check = func(history []collectionstest.Operation) []collectionstest.Operation {
+					return collectionstest.CheckLinearizabilityWithCapacity(history, test.capacity)
+				}
+			}
+			ops := check(test.history)
+			if len(ops) != test.minimal {
+				t.Fatalf("Expected a sub-history of %d operations, got %v", test.minimal, ops)
+			}
+			if ops != nil {
+				checkMinimal(t, check, test.history, ops)
+			}
+		})
+	}
+}
+
+func TestCheckLinearizabilityMinimal(t *testing.T) {
+	methods := []string{"Append", "Prepend", "Insert", "Get", "Delete", "Pop", "PopFirst", "Length", "IsEmpty"}
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 2000; n++ {
+		var history []collectionstest.Operation
+		for i := 2 + r.Intn(5); i > 0; i-- {
+			call := int64(r.Intn(10))
+			history = append(history, collectionstest.Operation{
+				Client: i,
+				Method: methods[r.Intn(len(methods))],
+				Index:  r.Intn(2),
+				Value:  r.Intn(3),
+				Result: collectionstest.Result{Value: r.Intn(3), OK: r.Intn(2) == 0},
+				Call:   call,
+				Return: call + 1 + int64(r.Intn(5)),
+			})
+		}
+		if ops := collectionstest.CheckLinearizability(history); ops != nil {
+			checkMinimal(t, collectionstest.CheckLinearizability, history, ops)
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	r := collectionstest.NewRecorder()
+	list := &dll.DoublyLinkedList[int]{}
+	var wg sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			l := r.List(c, list)
+			for i := 0; i < 10; i++ {
+				l.Append(c*10 + i)
+				l.Pop()
+			}
+			for range l.Range() {
+			}
+		}(c)
+	}
+	wg.Wait()
+	history := r.History()
+	if len(history) != 4*21 {
+		t.Fatalf("Expected %d operations, got %d", 4*21, len(history))
+	}
+	for _, op := range history {
+		if op.Call >= op.Return {
+			t.Fatalf("Expected %v to return after it was called", op)
+		}
+	}
+	collectionstest.AssertLinearizable(t, history)
+}
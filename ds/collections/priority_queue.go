@@ -0,0 +1,16 @@
+// Description: Priority queue interface.
+package collections
+
+// PriorityQueue represents a priority queue data structure.
+type PriorityQueue[T any] interface {
+	// Push adds an element to the queue.
+	Push(data T)
+	// Pop removes and returns the element with the top priority.
+	Pop() (T, bool)
+	// Peek returns the element with the top priority without removing it.
+	Peek() (T, bool)
+	// IsEmpty returns true if the queue is empty, false otherwise.
+	IsEmpty() bool
+	// Length returns the number of elements in the queue.
+	Length() int
+}
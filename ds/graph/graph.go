@@ -0,0 +1,469 @@
+// Description: This package contains the implementation of a graph and its traversal algorithms.
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mmygods/gods/ds/collections"
+	"github.com/mmygods/gods/ds/models/dll"
+	"github.com/mmygods/gods/ds/models/stack"
+)
+
+// ErrUndirected is returned by operations that are only defined on directed graphs.
+var ErrUndirected = errors.New("graph: operation requires a directed graph")
+
+// CycleError is returned by TopologicalSort when the graph contains a cycle.
+type CycleError[V comparable] struct {
+	// Cycle lists the vertices of the cycle in edge order; the last vertex has an edge to the first.
+	Cycle []V
+}
+
+func (e *CycleError[V]) Error() string {
+	return fmt.Sprintf("graph: cycle detected: %v", e.Cycle)
+}
+
+// edge represents a directed edge used for constant-time edge lookups.
+type edge[V comparable] struct {
+	from V
+	to   V
+}
+
+// The Graph struct represents a directed or undirected graph stored as adjacency lists.
+// Vertices and neighbors are kept in insertion order, so traversals are deterministic.
+type Graph[V comparable] struct {
+	directed  bool
+	vertices  []V
+	adjacency map[V][]V
+	edges     map[edge[V]]struct{}
+	mu        sync.RWMutex
+}
+
+// frame is a vertex on an explicit depth-first search stack with the index of its next neighbor.
+type frame[V comparable] struct {
+	vertex V
+	next   int
+}
+
+// NewDirected creates a new empty directed graph.
+func NewDirected[V comparable]() *Graph[V] {
+	return newGraph[V](true)
+}
+
+// NewUndirected creates a new empty undirected graph.
+func NewUndirected[V comparable]() *Graph[V] {
+	return newGraph[V](false)
+}
+
+func newGraph[V comparable](directed bool) *Graph[V] {
+	return &Graph[V]{
+		directed:  directed,
+		adjacency: make(map[V][]V),
+		edges:     make(map[edge[V]]struct{}),
+	}
+}
+
+// addVertex adds the vertex. It returns false if the vertex is already present.
+func (g *Graph[V]) addVertex(v V) bool {
+	if _, ok := g.adjacency[v]; ok {
+		return false
+	}
+	g.adjacency[v] = nil
+	g.vertices = append(g.vertices, v)
+	return true
+}
+
+// addEdge adds an edge between the vertices, adding them if necessary.
+func (g *Graph[V]) addEdge(from, to V) bool {
+	if _, ok := g.edges[edge[V]{from, to}]; ok {
+		return false
+	}
+	g.addVertex(from)
+	g.addVertex(to)
+	g.edges[edge[V]{from, to}] = struct{}{}
+	g.adjacency[from] = append(g.adjacency[from], to)
+	if !g.directed && from != to {
+		g.edges[edge[V]{to, from}] = struct{}{}
+		g.adjacency[to] = append(g.adjacency[to], from)
+	}
+	return true
+}
+
+// removeNeighbor removes to from the adjacency list of from.
+func (g *Graph[V]) removeNeighbor(from, to V) {
+	neighbors := g.adjacency[from]
+	for i, w := range neighbors {
+		if w == to {
+			g.adjacency[from] = append(neighbors[:i], neighbors[i+1:]...)
+			return
+		}
+	}
+}
+
+// removeEdge removes the edge between the vertices.
+func (g *Graph[V]) removeEdge(from, to V) bool {
+	if _, ok := g.edges[edge[V]{from, to}]; !ok {
+		return false
+	}
+	delete(g.edges, edge[V]{from, to})
+	g.removeNeighbor(from, to)
+	if !g.directed && from != to {
+		delete(g.edges, edge[V]{to, from})
+		g.removeNeighbor(to, from)
+	}
+	return true
+}
+
+// removeVertex removes the vertex and every edge touching it.
+func (g *Graph[V]) removeVertex(v V) bool {
+	if _, ok := g.adjacency[v]; !ok {
+		return false
+	}
+	for _, u := range g.vertices {
+		if _, ok := g.edges[edge[V]{u, v}]; ok {
+			delete(g.edges, edge[V]{u, v})
+			g.removeNeighbor(u, v)
+		}
+	}
+	for _, w := range g.adjacency[v] {
+		delete(g.edges, edge[V]{v, w})
+	}
+	delete(g.adjacency, v)
+	for i, u := range g.vertices {
+		if u == v {
+			g.vertices = append(g.vertices[:i], g.vertices[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// edgeCount returns the number of edges, counting each undirected edge once.
+func (g *Graph[V]) edgeCount() int {
+	if g.directed {
+		return len(g.edges)
+	}
+	loops := 0
+	for e := range g.edges {
+		if e.from == e.to {
+			loops++
+		}
+	}
+	return (len(g.edges)-loops)/2 + loops
+}
+
+// bfs calls fn for every vertex reachable from start in breadth-first order.
+func (g *Graph[V]) bfs(start V, fn func(V) bool) {
+	if _, ok := g.adjacency[start]; !ok {
+		return
+	}
+	var queue collections.Deque[V] = &dll.DoublyLinkedList[V]{}
+	visited := map[V]bool{start: true}
+	queue.Append(start)
+	for !queue.IsEmpty() {
+		v, _ := queue.PopFirst()
+		if !fn(v) {
+			return
+		}
+		for _, w := range g.adjacency[v] {
+			if !visited[w] {
+				visited[w] = true
+				queue.Append(w)
+			}
+		}
+	}
+}
+
+// dfs calls fn for every vertex reachable from start in depth-first preorder.
+func (g *Graph[V]) dfs(start V, fn func(V) bool) {
+	if _, ok := g.adjacency[start]; !ok {
+		return
+	}
+	var s collections.Stack[V] = stack.New[V]()
+	visited := map[V]bool{}
+	s.Push(start)
+	for !s.IsEmpty() {
+		v, _ := s.Pop()
+		if visited[v] {
+			continue
+		}
+		visited[v] = true
+		if !fn(v) {
+			return
+		}
+		neighbors := g.adjacency[v]
+		for i := len(neighbors) - 1; i >= 0; i-- {
+			if !visited[neighbors[i]] {
+				s.Push(neighbors[i])
+			}
+		}
+	}
+}
+
+// topologicalSort orders the vertices so that every edge points forward.
+func (g *Graph[V]) topologicalSort() ([]V, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+	const (
+		white = iota
+		gray
+		black
+	)
+	color := make(map[V]int, len(g.vertices))
+	parent := make(map[V]V, len(g.vertices))
+	order := make([]V, 0, len(g.vertices))
+	for _, root := range g.vertices {
+		if color[root] != white {
+			continue
+		}
+		frames := stack.New[frame[V]]()
+		frames.Push(frame[V]{vertex: root})
+		color[root] = gray
+		for !frames.IsEmpty() {
+			f, _ := frames.Pop()
+			neighbors := g.adjacency[f.vertex]
+			if f.next == len(neighbors) {
+				color[f.vertex] = black
+				order = append(order, f.vertex)
+				continue
+			}
+			w := neighbors[f.next]
+			f.next++
+			frames.Push(f)
+			switch color[w] {
+			case white:
+				color[w] = gray
+				parent[w] = f.vertex
+				frames.Push(frame[V]{vertex: w})
+			case gray:
+				cycle := []V{f.vertex}
+				for v := f.vertex; v != w; v = parent[v] {
+					cycle = append(cycle, parent[v])
+				}
+				reverse(cycle)
+				return nil, &CycleError[V]{Cycle: cycle}
+			}
+		}
+	}
+	reverse(order)
+	return order, nil
+}
+
+// stronglyConnectedComponents returns the components found by Tarjan's algorithm.
+func (g *Graph[V]) stronglyConnectedComponents() [][]V {
+	index := make(map[V]int, len(g.vertices))
+	lowlink := make(map[V]int, len(g.vertices))
+	onStack := make(map[V]bool, len(g.vertices))
+	pending := stack.New[V]()
+	var components [][]V
+	counter := 0
+	visit := func(v V) {
+		index[v] = counter
+		lowlink[v] = counter
+		counter++
+		pending.Push(v)
+		onStack[v] = true
+	}
+	for _, root := range g.vertices {
+		if _, ok := index[root]; ok {
+			continue
+		}
+		frames := stack.New[frame[V]]()
+		frames.Push(frame[V]{vertex: root})
+		visit(root)
+		for !frames.IsEmpty() {
+			f, _ := frames.Pop()
+			neighbors := g.adjacency[f.vertex]
+			if f.next < len(neighbors) {
+				w := neighbors[f.next]
+				f.next++
+				frames.Push(f)
+				if _, ok := index[w]; !ok {
+					visit(w)
+					frames.Push(frame[V]{vertex: w})
+				} else if onStack[w] {
+					lowlink[f.vertex] = min(lowlink[f.vertex], index[w])
+				}
+				continue
+			}
+			if lowlink[f.vertex] == index[f.vertex] {
+				var component []V
+				for {
+					w, _ := pending.Pop()
+					onStack[w] = false
+					component = append(component, w)
+					if w == f.vertex {
+						break
+					}
+				}
+				components = append(components, component)
+			}
+			if caller, ok := frames.Peek(); ok {
+				lowlink[caller.vertex] = min(lowlink[caller.vertex], lowlink[f.vertex])
+			}
+		}
+	}
+	return components
+}
+
+func reverse[V any](s []V) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// IsDirected returns true if the graph is directed.
+func (g *Graph[V]) IsDirected() bool {
+	return g.directed
+}
+
+// AddVertex adds the vertex in a concurrency-safe manner.
+// It returns false if the vertex is already present.
+func (g *Graph[V]) AddVertex(v V) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.addVertex(v)
+}
+
+// AddEdge adds an edge between the vertices in a concurrency-safe manner, adding the
+// vertices if necessary. It returns false if the edge is already present.
+func (g *Graph[V]) AddEdge(from, to V) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.addEdge(from, to)
+}
+
+// RemoveEdge removes the edge between the vertices in a concurrency-safe manner.
+func (g *Graph[V]) RemoveEdge(from, to V) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.removeEdge(from, to)
+}
+
+// RemoveVertex removes the vertex and its edges in a concurrency-safe manner.
+func (g *Graph[V]) RemoveVertex(v V) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.removeVertex(v)
+}
+
+// HasVertex returns true if the vertex is present in a concurrency-safe manner.
+func (g *Graph[V]) HasVertex(v V) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	_, ok := g.adjacency[v]
+	return ok
+}
+
+// HasEdge returns true if the edge is present in a concurrency-safe manner.
+func (g *Graph[V]) HasEdge(from, to V) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	_, ok := g.edges[edge[V]{from, to}]
+	return ok
+}
+
+// Neighbors returns the vertices adjacent to v in a concurrency-safe manner.
+func (g *Graph[V]) Neighbors(v V) ([]V, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	neighbors, ok := g.adjacency[v]
+	if !ok {
+		return nil, false
+	}
+	return append([]V(nil), neighbors...), true
+}
+
+// Vertices returns the vertices in insertion order in a concurrency-safe manner.
+func (g *Graph[V]) Vertices() []V {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return append([]V(nil), g.vertices...)
+}
+
+// VertexCount returns the number of vertices in a concurrency-safe manner.
+func (g *Graph[V]) VertexCount() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.vertices)
+}
+
+// EdgeCount returns the number of edges in a concurrency-safe manner.
+// Each undirected edge is counted once.
+func (g *Graph[V]) EdgeCount() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.edgeCount()
+}
+
+// traverse sends the vertices produced by walk to a channel while holding the read lock.
+func (g *Graph[V]) traverse(walk func(start V, fn func(V) bool), start V) <-chan V {
+	g.mu.RLock()
+	ch := make(chan V)
+	go func() {
+		defer g.mu.RUnlock()
+		walk(start, func(v V) bool {
+			ch <- v
+			return true
+		})
+		close(ch)
+	}()
+	return ch
+}
+
+// BFS returns a channel that iterates over the vertices reachable from start in
+// breadth-first order in a concurrency-safe manner.
+func (g *Graph[V]) BFS(start V) <-chan V {
+	return g.traverse(g.bfs, start)
+}
+
+// DFS returns a channel that iterates over the vertices reachable from start in
+// depth-first preorder in a concurrency-safe manner.
+func (g *Graph[V]) DFS(start V) <-chan V {
+	return g.traverse(g.dfs, start)
+}
+
+// TopologicalSort returns the vertices ordered so that every edge points forward, in a
+// concurrency-safe manner. It returns a *CycleError if the graph has a cycle and
+// ErrUndirected if the graph is undirected.
+func (g *Graph[V]) TopologicalSort() ([]V, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.topologicalSort()
+}
+
+// StronglyConnectedComponents returns the strongly connected components in a concurrency-safe
+// manner, in reverse topological order of the condensed graph. For an undirected graph these
+// are its connected components.
+func (g *Graph[V]) StronglyConnectedComponents() [][]V {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.stronglyConnectedComponents()
+}
+
+// Reachable returns true if there is a path from one vertex to the other in a concurrency-safe manner.
+func (g *Graph[V]) Reachable(from, to V) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	found := false
+	g.bfs(from, func(v V) bool {
+		found = v == to
+		return !found
+	})
+	return found
+}
+
+// ReachableFrom returns every vertex reachable from start, including start, in breadth-first
+// order in a concurrency-safe manner.
+func (g *Graph[V]) ReachableFrom(start V) []V {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var reachable []V
+	g.bfs(start, func(v V) bool {
+		reachable = append(reachable, v)
+		return true
+	})
+	return reachable
+}
@@ -0,0 +1,163 @@
+package graph_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/mmygods/gods/ds/graph"
+)
+
+func collect(ch <-chan string) []string {
+	var out []string
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGraphEdges(t *testing.T) {
+	tests := []struct {
+		name    string
+		graph   *graph.Graph[string]
+		reverse bool
+	}{
+		{name: "Directed", graph: graph.NewDirected[string](), reverse: false},
+		{name: "Undirected", graph: graph.NewUndirected[string](), reverse: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := test.graph
+			g.AddEdge("a", "b")
+			g.AddEdge("b", "c")
+			g.AddEdge("c", "c")
+			if g.AddEdge("a", "b") {
+				t.Error("Expected duplicate edge to be rejected")
+			}
+			if g.EdgeCount() != 3 {
+				t.Errorf("Expected 3 edges, got %d", g.EdgeCount())
+			}
+			if g.HasEdge("b", "a") != test.reverse {
+				t.Errorf("Expected HasEdge(b, a) to be %t", test.reverse)
+			}
+			if !g.RemoveVertex("b") {
+				t.Error("Expected RemoveVertex to return true")
+			}
+			if g.VertexCount() != 2 || g.EdgeCount() != 1 {
+				t.Errorf("Expected 2 vertices and 1 edge, got %d and %d", g.VertexCount(), g.EdgeCount())
+			}
+			if neighbors, _ := g.Neighbors("a"); len(neighbors) != 0 {
+				t.Errorf("Expected a to have no neighbors, got %v", neighbors)
+			}
+			if !g.RemoveEdge("c", "c") || g.EdgeCount() != 0 {
+				t.Error("Expected self-loop to be removed")
+			}
+		})
+	}
+}
+
+func TestGraphTraversal(t *testing.T) {
+	g := graph.NewDirected[string]()
+	g.AddEdge("a", "b")
+	g.AddEdge("a", "c")
+	g.AddEdge("b", "d")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "e")
+	g.AddVertex("f")
+	if got := collect(g.BFS("a")); !equal(got, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Unexpected BFS order %v", got)
+	}
+	if got := collect(g.DFS("a")); !equal(got, []string{"a", "b", "d", "e", "c"}) {
+		t.Errorf("Unexpected DFS order %v", got)
+	}
+	if got := collect(g.BFS("missing")); got != nil {
+		t.Errorf("Expected no vertices from a missing start, got %v", got)
+	}
+	if !g.Reachable("a", "e") || g.Reachable("e", "a") || g.Reachable("a", "f") {
+		t.Error("Reachable returned an unexpected result")
+	}
+	if got := g.ReachableFrom("c"); !equal(got, []string{"c", "d", "e"}) {
+		t.Errorf("Unexpected ReachableFrom result %v", got)
+	}
+}
+
+func TestGraphTopologicalSort(t *testing.T) {
+	g := graph.NewDirected[string]()
+	g.AddEdge("shirt", "tie")
+	g.AddEdge("tie", "jacket")
+	g.AddEdge("trousers", "shoes")
+	g.AddEdge("trousers", "belt")
+	g.AddEdge("belt", "jacket")
+	g.AddEdge("shirt", "belt")
+	order, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	position := map[string]int{}
+	for i, v := range order {
+		position[v] = i
+	}
+	for _, v := range g.Vertices() {
+		neighbors, _ := g.Neighbors(v)
+		for _, w := range neighbors {
+			if position[v] >= position[w] {
+				t.Errorf("Edge %s -> %s points backwards in %v", v, w, order)
+			}
+		}
+	}
+
+	g.AddEdge("jacket", "shirt")
+	_, err = g.TopologicalSort()
+	var cycleErr *graph.CycleError[string]
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Expected a cycle error, got %v", err)
+	}
+	cycle := cycleErr.Cycle
+	for i, v := range cycle {
+		if !g.HasEdge(v, cycle[(i+1)%len(cycle)]) {
+			t.Errorf("Reported cycle %v is not a cycle", cycle)
+		}
+	}
+
+	if _, err := graph.NewUndirected[int]().TopologicalSort(); !errors.Is(err, graph.ErrUndirected) {
+		t.Errorf("Expected ErrUndirected, got %v", err)
+	}
+}
+
+func TestGraphStronglyConnectedComponents(t *testing.T) {
+	g := graph.NewDirected[int]()
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 4}, {6, 6}} {
+		g.AddEdge(e[0], e[1])
+	}
+	components := g.StronglyConnectedComponents()
+	for _, component := range components {
+		sort.Ints(component)
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+	expected := [][]int{{1, 2, 3}, {4, 5}, {6}}
+	if len(components) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, components)
+	}
+	for i := range expected {
+		if len(components[i]) != len(expected[i]) {
+			t.Fatalf("Expected %v, got %v", expected, components)
+		}
+		for j := range expected[i] {
+			if components[i][j] != expected[i][j] {
+				t.Errorf("Expected %v, got %v", expected, components)
+			}
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mmygods/gods/ds/models/heap"
+)
+
+var (
+	// ErrVertexNotFound is returned when a vertex passed to an algorithm is not in the graph.
+	ErrVertexNotFound = errors.New("graph: vertex not found")
+	// ErrNegativeWeight is returned by algorithms that require non-negative edge weights.
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+	// ErrNoPath is returned when the target cannot be reached from the source.
+	ErrNoPath = errors.New("graph: no path")
+)
+
+// NegativeCycleError is returned by BellmanFord when a negative cycle is reachable from the source.
+type NegativeCycleError[V comparable] struct {
+	// Cycle lists the vertices of the cycle in edge order; the last vertex has an edge to the first.
+	Cycle []V
+}
+
+func (e *NegativeCycleError[V]) Error() string {
+	return fmt.Sprintf("graph: negative cycle detected: %v", e.Cycle)
+}
+
+// ShortestPaths holds the distances and predecessors computed from a single source.
+type ShortestPaths[V comparable, W Weight] struct {
+	source   V
+	distance map[V]W
+	previous map[V]V
+}
+
+// Source returns the vertex the paths start from.
+func (p *ShortestPaths[V, W]) Source() V {
+	return p.source
+}
+
+// Distance returns the length of the shortest path to v, and false if v is unreachable.
+func (p *ShortestPaths[V, W]) Distance(v V) (W, bool) {
+	distance, ok := p.distance[v]
+	return distance, ok
+}
+
+// PathTo returns the vertices of the shortest path from the source to v, and false if v is unreachable.
+func (p *ShortestPaths[V, W]) PathTo(v V) ([]V, bool) {
+	if _, ok := p.distance[v]; !ok {
+		return nil, false
+	}
+	return buildPath(p.previous, p.source, v), true
+}
+
+// buildPath follows previous from target back to source and returns the path in forward order.
+func buildPath[V comparable](previous map[V]V, source, target V) []V {
+	path := []V{target}
+	for v := target; v != source; {
+		v = previous[v]
+		path = append(path, v)
+	}
+	reverse(path)
+	return path
+}
+
+// queued is a vertex in a priority queue, ordered by priority.
+type queued[V comparable, W Weight] struct {
+	vertex   V
+	distance W
+	priority W
+}
+
+func newQueue[V comparable, W Weight]() *heap.Heap[queued[V, W]] {
+	return heap.NewWithLess(func(a, b queued[V, W]) bool {
+		return a.priority < b.priority
+	})
+}
+
+// dijkstra computes shortest paths from source. Stale queue entries are skipped rather than updated.
+func (g *WeightedGraph[V, W]) dijkstra(source V) (*ShortestPaths[V, W], error) {
+	if _, ok := g.adjacency[source]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	var zero W
+	paths := &ShortestPaths[V, W]{
+		source:   source,
+		distance: map[V]W{source: zero},
+		previous: map[V]V{},
+	}
+	done := map[V]bool{}
+	queue := newQueue[V, W]()
+	queue.Push(queued[V, W]{vertex: source})
+	for !queue.IsEmpty() {
+		item, _ := queue.Pop()
+		if done[item.vertex] {
+			continue
+		}
+		done[item.vertex] = true
+		for _, e := range g.adjacency[item.vertex] {
+			if e.Weight < zero {
+				return nil, ErrNegativeWeight
+			}
+			distance := item.distance + e.Weight
+			if current, ok := paths.distance[e.To]; !ok || distance < current {
+				paths.distance[e.To] = distance
+				paths.previous[e.To] = item.vertex
+				queue.Push(queued[V, W]{vertex: e.To, distance: distance, priority: distance})
+			}
+		}
+	}
+	return paths, nil
+}
+
+// bellmanFord computes shortest paths from source, allowing negative edge weights.
+func (g *WeightedGraph[V, W]) bellmanFord(source V) (*ShortestPaths[V, W], error) {
+	if _, ok := g.adjacency[source]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	var zero W
+	paths := &ShortestPaths[V, W]{
+		source:   source,
+		distance: map[V]W{source: zero},
+		previous: map[V]V{},
+	}
+	// relax performs one pass over every edge and returns the last vertex whose distance changed.
+	relax := func() (V, bool) {
+		var last V
+		changed := false
+		for _, v := range g.vertices {
+			distance, ok := paths.distance[v]
+			if !ok {
+				continue
+			}
+			for _, e := range g.adjacency[v] {
+				if current, ok := paths.distance[e.To]; !ok || distance+e.Weight < current {
+					paths.distance[e.To] = distance + e.Weight
+					paths.previous[e.To] = v
+					last, changed = e.To, true
+				}
+			}
+		}
+		return last, changed
+	}
+	for i := 1; i < len(g.vertices); i++ {
+		if _, changed := relax(); !changed {
+			return paths, nil
+		}
+	}
+	v, changed := relax()
+	if !changed {
+		return paths, nil
+	}
+	// Walking back |V| predecessors from a vertex updated in the extra round lands on the cycle.
+	for i := 0; i < len(g.vertices); i++ {
+		v = paths.previous[v]
+	}
+	cycle := []V{v}
+	for u := paths.previous[v]; u != v; u = paths.previous[u] {
+		cycle = append(cycle, u)
+	}
+	reverse(cycle)
+	return nil, &NegativeCycleError[V]{Cycle: cycle}
+}
+
+// aStar finds a shortest path from source to target guided by an admissible heuristic.
+func (g *WeightedGraph[V, W]) aStar(source, target V, heuristic func(V) W) ([]V, W, error) {
+	var zero W
+	if _, ok := g.adjacency[source]; !ok {
+		return nil, zero, ErrVertexNotFound
+	}
+	if _, ok := g.adjacency[target]; !ok {
+		return nil, zero, ErrVertexNotFound
+	}
+	distance := map[V]W{source: zero}
+	previous := map[V]V{}
+	queue := newQueue[V, W]()
+	queue.Push(queued[V, W]{vertex: source, priority: heuristic(source)})
+	for !queue.IsEmpty() {
+		item, _ := queue.Pop()
+		if item.distance > distance[item.vertex] {
+			continue
+		}
+		if item.vertex == target {
+			return buildPath(previous, source, target), item.distance, nil
+		}
+		for _, e := range g.adjacency[item.vertex] {
+			if e.Weight < zero {
+				return nil, zero, ErrNegativeWeight
+			}
+			next := item.distance + e.Weight
+			if current, ok := distance[e.To]; !ok || next < current {
+				distance[e.To] = next
+				previous[e.To] = item.vertex
+				queue.Push(queued[V, W]{vertex: e.To, distance: next, priority: next + heuristic(e.To)})
+			}
+		}
+	}
+	return nil, zero, ErrNoPath
+}
+
+// Dijkstra computes the shortest paths from source in a concurrency-safe manner.
+// It returns ErrNegativeWeight if a reachable edge has a negative weight.
+func (g *WeightedGraph[V, W]) Dijkstra(source V) (*ShortestPaths[V, W], error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.dijkstra(source)
+}
+
+// BellmanFord computes the shortest paths from source in a concurrency-safe manner, allowing
+// negative edge weights. It returns a *NegativeCycleError if a negative cycle is reachable.
+func (g *WeightedGraph[V, W]) BellmanFord(source V) (*ShortestPaths[V, W], error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.bellmanFord(source)
+}
+
+// AStar returns a shortest path from source to target and its length in a concurrency-safe
+// manner. The heuristic estimates the remaining distance to target and must never overestimate it.
+// It returns ErrNoPath if target is unreachable.
+func (g *WeightedGraph[V, W]) AStar(source, target V, heuristic func(V) W) ([]V, W, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.aStar(source, target, heuristic)
+}
@@ -0,0 +1,153 @@
+package graph_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/mmygods/gods/ds/graph"
+)
+
+func buildWeighted(edges []graph.Edge[string, int]) *graph.WeightedGraph[string, int] {
+	g := graph.NewWeightedDirected[string, int]()
+	for _, e := range edges {
+		g.AddEdge(e.From, e.To, e.Weight)
+	}
+	return g
+}
+
+func TestDijkstra(t *testing.T) {
+	g := buildWeighted([]graph.Edge[string, int]{
+		{From: "a", To: "b", Weight: 4},
+		{From: "a", To: "c", Weight: 1},
+		{From: "c", To: "b", Weight: 2},
+		{From: "b", To: "d", Weight: 1},
+		{From: "c", To: "d", Weight: 5},
+	})
+	g.AddVertex("e")
+	paths, err := g.Dijkstra("a")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if distance, _ := paths.Distance("d"); distance != 4 {
+		t.Errorf("Expected distance 4, got %d", distance)
+	}
+	if path, _ := paths.PathTo("d"); !equal(path, []string{"a", "c", "b", "d"}) {
+		t.Errorf("Unexpected path %v", path)
+	}
+	if _, ok := paths.PathTo("e"); ok {
+		t.Error("Expected unreachable vertex to have no path")
+	}
+	if _, err := g.Dijkstra("missing"); !errors.Is(err, graph.ErrVertexNotFound) {
+		t.Errorf("Expected ErrVertexNotFound, got %v", err)
+	}
+	g.AddEdge("d", "a", -1)
+	if _, err := g.Dijkstra("a"); !errors.Is(err, graph.ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestBellmanFord(t *testing.T) {
+	g := buildWeighted([]graph.Edge[string, int]{
+		{From: "a", To: "b", Weight: 4},
+		{From: "a", To: "c", Weight: 5},
+		{From: "c", To: "b", Weight: -3},
+		{From: "b", To: "d", Weight: 2},
+	})
+	paths, err := g.BellmanFord("a")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if distance, _ := paths.Distance("d"); distance != 4 {
+		t.Errorf("Expected distance 4, got %d", distance)
+	}
+	if path, _ := paths.PathTo("d"); !equal(path, []string{"a", "c", "b", "d"}) {
+		t.Errorf("Unexpected path %v", path)
+	}
+
+	g.AddEdge("d", "c", 0)
+	_, err = g.BellmanFord("a")
+	var cycleErr *graph.NegativeCycleError[string]
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Expected a negative cycle error, got %v", err)
+	}
+	total := 0
+	for i, v := range cycleErr.Cycle {
+		weight, ok := g.EdgeWeight(v, cycleErr.Cycle[(i+1)%len(cycleErr.Cycle)])
+		if !ok {
+			t.Fatalf("Reported cycle %v is not a cycle", cycleErr.Cycle)
+		}
+		total += weight
+	}
+	if total >= 0 {
+		t.Errorf("Expected reported cycle %v to be negative, got weight %d", cycleErr.Cycle, total)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	type point struct{ x, y int }
+	g := graph.NewWeightedUndirected[point, int]()
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if x == 5 && y < 9 {
+				continue
+			}
+			if x+1 < 10 && !(x+1 == 5 && y < 9) {
+				g.AddEdge(point{x, y}, point{x + 1, y}, 1)
+			}
+			if y+1 < 10 && !(x == 5 && y+1 < 9) {
+				g.AddEdge(point{x, y}, point{x, y + 1}, 1)
+			}
+		}
+	}
+	target := point{9, 0}
+	manhattan := func(p point) int {
+		dx, dy := p.x-target.x, p.y-target.y
+		if dx < 0 {
+			dx = -dx
+		}
+		if dy < 0 {
+			dy = -dy
+		}
+		return dx + dy
+	}
+	path, distance, err := g.AStar(point{0, 0}, target, manhattan)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if distance != 27 || len(path) != 28 {
+		t.Errorf("Expected a path of length 27 around the wall, got %d with %d vertices", distance, len(path))
+	}
+	g.AddVertex(point{-1, -1})
+	if _, _, err := g.AStar(point{0, 0}, point{-1, -1}, manhattan); !errors.Is(err, graph.ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+}
+
+func TestShortestPathsAgree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := graph.NewWeightedDirected[int, float64]()
+	for i := 0; i < 300; i++ {
+		g.AddEdge(r.Intn(50), r.Intn(50), r.Float64()*10)
+	}
+	dijkstra, err := g.Dijkstra(0)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	bellmanFord, err := g.BellmanFord(0)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	zero := func(int) float64 { return 0 }
+	for _, v := range g.Vertices() {
+		d1, ok1 := dijkstra.Distance(v)
+		d2, ok2 := bellmanFord.Distance(v)
+		if ok1 != ok2 || d1-d2 > 1e-9 || d2-d1 > 1e-9 {
+			t.Errorf("Distances to %d disagree: %g and %g", v, d1, d2)
+		}
+		_, d3, err := g.AStar(0, v, zero)
+		if ok1 != (err == nil) || (ok1 && (d1-d3 > 1e-9 || d3-d1 > 1e-9)) {
+			t.Errorf("A* distance to %d disagrees: %g and %g", v, d1, d3)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package graph
+
+import "sync"
+
+// Weight is the set of numeric types usable as edge weights.
+type Weight interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Edge represents a weighted edge between two vertices.
+type Edge[V comparable, W Weight] struct {
+	From   V
+	To     V
+	Weight W
+}
+
+// The WeightedGraph struct represents a directed or undirected graph with weighted edges.
+// Vertices and neighbors are kept in insertion order, so algorithms are deterministic.
+type WeightedGraph[V comparable, W Weight] struct {
+	directed  bool
+	vertices  []V
+	adjacency map[V][]Edge[V, W]
+	edges     map[edge[V]]W
+	mu        sync.RWMutex
+}
+
+// NewWeightedDirected creates a new empty directed weighted graph.
+func NewWeightedDirected[V comparable, W Weight]() *WeightedGraph[V, W] {
+	return newWeightedGraph[V, W](true)
+}
+
+// NewWeightedUndirected creates a new empty undirected weighted graph.
+func NewWeightedUndirected[V comparable, W Weight]() *WeightedGraph[V, W] {
+	return newWeightedGraph[V, W](false)
+}
+
+func newWeightedGraph[V comparable, W Weight](directed bool) *WeightedGraph[V, W] {
+	return &WeightedGraph[V, W]{
+		directed:  directed,
+		adjacency: make(map[V][]Edge[V, W]),
+		edges:     make(map[edge[V]]W),
+	}
+}
+
+// addVertex adds the vertex. It returns false if the vertex is already present.
+func (g *WeightedGraph[V, W]) addVertex(v V) bool {
+	if _, ok := g.adjacency[v]; ok {
+		return false
+	}
+	g.adjacency[v] = nil
+	g.vertices = append(g.vertices, v)
+	return true
+}
+
+// setEdge adds the directed edge or updates its weight.
+func (g *WeightedGraph[V, W]) setEdge(from, to V, weight W) {
+	g.edges[edge[V]{from, to}] = weight
+	for i, e := range g.adjacency[from] {
+		if e.To == to {
+			g.adjacency[from][i].Weight = weight
+			return
+		}
+	}
+	g.adjacency[from] = append(g.adjacency[from], Edge[V, W]{From: from, To: to, Weight: weight})
+}
+
+// addEdge adds an edge between the vertices, adding them if necessary.
+// It returns false if the edge was already present, in which case its weight is updated.
+func (g *WeightedGraph[V, W]) addEdge(from, to V, weight W) bool {
+	_, exists := g.edges[edge[V]{from, to}]
+	g.addVertex(from)
+	g.addVertex(to)
+	g.setEdge(from, to, weight)
+	if !g.directed && from != to {
+		g.setEdge(to, from, weight)
+	}
+	return !exists
+}
+
+// removeNeighbor removes the edge to to from the adjacency list of from.
+func (g *WeightedGraph[V, W]) removeNeighbor(from, to V) {
+	neighbors := g.adjacency[from]
+	for i, e := range neighbors {
+		if e.To == to {
+			g.adjacency[from] = append(neighbors[:i], neighbors[i+1:]...)
+			return
+		}
+	}
+}
+
+// removeEdge removes the edge between the vertices.
+func (g *WeightedGraph[V, W]) removeEdge(from, to V) bool {
+	if _, ok := g.edges[edge[V]{from, to}]; !ok {
+		return false
+	}
+	delete(g.edges, edge[V]{from, to})
+	g.removeNeighbor(from, to)
+	if !g.directed && from != to {
+		delete(g.edges, edge[V]{to, from})
+		g.removeNeighbor(to, from)
+	}
+	return true
+}
+
+// IsDirected returns true if the graph is directed.
+func (g *WeightedGraph[V, W]) IsDirected() bool {
+	return g.directed
+}
+
+// AddVertex adds the vertex in a concurrency-safe manner.
+// It returns false if the vertex is already present.
+func (g *WeightedGraph[V, W]) AddVertex(v V) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.addVertex(v)
+}
+
+// AddEdge adds a weighted edge between the vertices in a concurrency-safe manner, adding the
+// vertices if necessary. If the edge is already present its weight is updated and false is returned.
+func (g *WeightedGraph[V, W]) AddEdge(from, to V, weight W) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.addEdge(from, to, weight)
+}
+
+// RemoveEdge removes the edge between the vertices in a concurrency-safe manner.
+func (g *WeightedGraph[V, W]) RemoveEdge(from, to V) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.removeEdge(from, to)
+}
+
+// HasVertex returns true if the vertex is present in a concurrency-safe manner.
+func (g *WeightedGraph[V, W]) HasVertex(v V) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	_, ok := g.adjacency[v]
+	return ok
+}
+
+// EdgeWeight returns the weight of the edge between the vertices in a concurrency-safe manner.
+func (g *WeightedGraph[V, W]) EdgeWeight(from, to V) (W, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	weight, ok := g.edges[edge[V]{from, to}]
+	return weight, ok
+}
+
+// Edges returns the edges leaving v in a concurrency-safe manner.
+func (g *WeightedGraph[V, W]) Edges(v V) ([]Edge[V, W], bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	edges, ok := g.adjacency[v]
+	if !ok {
+		return nil, false
+	}
+	return append([]Edge[V, W](nil), edges...), true
+}
+
+// Vertices returns the vertices in insertion order in a concurrency-safe manner.
+func (g *WeightedGraph[V, W]) Vertices() []V {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return append([]V(nil), g.vertices...)
+}
+
+// VertexCount returns the number of vertices in a concurrency-safe manner.
+func (g *WeightedGraph[V, W]) VertexCount() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.vertices)
+}
@@ -0,0 +1,51 @@
+// Description: This package contains deterministic hash functions for probabilistic data structures.
+// Unlike hash/maphash, the hashes are stable across processes, so structures built
+// with them can be serialized in one process and loaded in another.
+package hashing
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// Hasher maps a key to a 64-bit hash.
+type Hasher[T any] func(key T) uint64
+
+// String hashes a string with 64-bit FNV-1a followed by a finalizer.
+func String(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return Mix64(h.Sum64())
+}
+
+// Bytes hashes a byte slice with 64-bit FNV-1a followed by a finalizer.
+func Bytes(key []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(key)
+	return Mix64(h.Sum64())
+}
+
+// Uint64 hashes an unsigned integer.
+func Uint64(key uint64) uint64 {
+	return Mix64(key)
+}
+
+// Int hashes a signed integer.
+func Int(key int) uint64 {
+	return Mix64(uint64(key))
+}
+
+// Float64 hashes a float by its IEEE 754 bits.
+func Float64(key float64) uint64 {
+	return Mix64(math.Float64bits(key))
+}
+
+// Mix64 scrambles the bits of x with the SplitMix64 finalizer.
+func Mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
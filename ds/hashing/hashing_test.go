@@ -0,0 +1,41 @@
+package hashing_test
+
+import (
+	"testing"
+
+	"github.com/mmygods/gods/ds/hashing"
+)
+
+func TestHashingIsStable(t *testing.T) {
+	// Serialized structures depend on these values never changing.
+	tests := []struct {
+		name     string
+		hash     uint64
+		expected uint64
+	}{
+		{name: "String", hash: hashing.String("gods"), expected: 0x8e1d2ec30d4e2f0a},
+		{name: "Bytes", hash: hashing.Bytes([]byte("gods")), expected: 0x8e1d2ec30d4e2f0a},
+		{name: "Int", hash: hashing.Int(42), expected: 0xa759ea27d4727622},
+		{name: "Uint64", hash: hashing.Uint64(42), expected: 0xa759ea27d4727622},
+		{name: "Float64", hash: hashing.Float64(1.5), expected: 0xe72b41d4576e3468},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.hash != test.expected {
+				t.Errorf("Expected %#x, got %#x", test.expected, test.hash)
+			}
+		})
+	}
+}
+
+func TestHashingSpreadsKeys(t *testing.T) {
+	buckets := make([]int, 16)
+	for i := 0; i < 16000; i++ {
+		buckets[hashing.Int(i)%16]++
+	}
+	for i, count := range buckets {
+		if count < 800 || count > 1200 {
+			t.Errorf("Bucket %d received %d of 16000 keys", i, count)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Description: This package contains the implementation of a bidirectional map.
+package bimap
+
+import "sync"
+
+// Entry represents a single key-value pair stored in the bidirectional map.
+type Entry[K comparable, V comparable] struct {
+	Key   K
+	Value V
+}
+
+// The BiMap struct represents a map enforcing a one-to-one mapping between keys and values.
+type BiMap[K comparable, V comparable] struct {
+	forward map[K]V
+	inverse map[V]K
+	mu      *sync.RWMutex
+}
+
+func zeroValue[T any]() T {
+	var zero T
+	return zero
+}
+
+// New creates a new bidirectional map.
+func New[K comparable, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: make(map[K]V),
+		inverse: make(map[V]K),
+		mu:      &sync.RWMutex{},
+	}
+}
+
+// put associates the key with the value if neither is bound to another element.
+func (b *BiMap[K, V]) put(key K, value V) bool {
+	if current, ok := b.forward[key]; ok {
+		return current == value
+	}
+	if _, ok := b.inverse[value]; ok {
+		return false
+	}
+	b.forward[key] = value
+	b.inverse[value] = key
+	return true
+}
+
+// forcePut associates the key with the value, removing any existing mapping of either.
+func (b *BiMap[K, V]) forcePut(key K, value V) {
+	b.removeKey(key)
+	b.removeValue(value)
+	b.forward[key] = value
+	b.inverse[value] = key
+}
+
+// removeKey removes the mapping for the key.
+func (b *BiMap[K, V]) removeKey(key K) (V, bool) {
+	value, ok := b.forward[key]
+	if !ok {
+		return zeroValue[V](), false
+	}
+	delete(b.forward, key)
+	delete(b.inverse, value)
+	return value, true
+}
+
+// removeValue removes the mapping for the value.
+func (b *BiMap[K, V]) removeValue(value V) (K, bool) {
+	key, ok := b.inverse[value]
+	if !ok {
+		return zeroValue[K](), false
+	}
+	delete(b.inverse, value)
+	delete(b.forward, key)
+	return key, true
+}
+
+// Put associates the key with the value in a concurrency-safe manner.
+// It returns false if the key or the value is already bound to a different element.
+func (b *BiMap[K, V]) Put(key K, value V) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.put(key, value)
+}
+
+// ForcePut associates the key with the value in a concurrency-safe manner,
+// replacing any existing mapping of the key or the value.
+func (b *BiMap[K, V]) ForcePut(key K, value V) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.forcePut(key, value)
+}
+
+// Get returns the value associated with the key in a concurrency-safe manner.
+func (b *BiMap[K, V]) Get(key K) (V, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	value, ok := b.forward[key]
+	return value, ok
+}
+
+// GetKey returns the key associated with the value in a concurrency-safe manner.
+func (b *BiMap[K, V]) GetKey(value V) (K, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	key, ok := b.inverse[value]
+	return key, ok
+}
+
+// ContainsKey returns true if the key is present in a concurrency-safe manner.
+func (b *BiMap[K, V]) ContainsKey(key K) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.forward[key]
+	return ok
+}
+
+// ContainsValue returns true if the value is present in a concurrency-safe manner.
+func (b *BiMap[K, V]) ContainsValue(value V) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.inverse[value]
+	return ok
+}
+
+// Remove removes the mapping for the key and returns its value in a concurrency-safe manner.
+func (b *BiMap[K, V]) Remove(key K) (V, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.removeKey(key)
+}
+
+// RemoveValue removes the mapping for the value and returns its key in a concurrency-safe manner.
+func (b *BiMap[K, V]) RemoveValue(value V) (K, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.removeValue(value)
+}
+
+// Length returns the number of mappings in a concurrency-safe manner.
+func (b *BiMap[K, V]) Length() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.forward)
+}
+
+// IsEmpty returns true if the map holds no mappings in a concurrency-safe manner.
+func (b *BiMap[K, V]) IsEmpty() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.forward) == 0
+}
+
+// Inverse returns a view of the map with keys and values swapped.
+// The view shares storage and locking with the original, so changes to either are visible in both.
+func (b *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return &BiMap[V, K]{
+		forward: b.inverse,
+		inverse: b.forward,
+		mu:      b.mu,
+	}
+}
+
+// Range returns a channel that iterates over all key-value pairs in a concurrency-safe manner.
+// The order of pairs is unspecified.
+func (b *BiMap[K, V]) Range() <-chan Entry[K, V] {
+	b.mu.RLock()
+	ch := make(chan Entry[K, V])
+	go func() {
+		defer b.mu.RUnlock()
+		for key, value := range b.forward {
+			ch <- Entry[K, V]{Key: key, Value: value}
+		}
+		close(ch)
+	}()
+	return ch
+}
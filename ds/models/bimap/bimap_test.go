@@ -0,0 +1,127 @@
+package bimap_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/bimap"
+)
+
+func TestBiMapPut(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    int
+		expected bool
+	}{
+		{
+			name:     "Put new pair",
+			key:      "c",
+			value:    3,
+			expected: true,
+		},
+		{
+			name:     "Put existing pair",
+			key:      "a",
+			value:    1,
+			expected: true,
+		},
+		{
+			name:     "Put existing key with new value",
+			key:      "a",
+			value:    3,
+			expected: false,
+		},
+		{
+			name:     "Put new key with existing value",
+			key:      "c",
+			value:    1,
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := bimap.New[string, int]()
+			b.Put("a", 1)
+			b.Put("b", 2)
+			if b.Put(test.key, test.value) != test.expected {
+				t.Errorf("Expected Put to return %t", test.expected)
+			}
+			if b.Length() != b.Inverse().Length() {
+				t.Errorf("Forward and inverse lengths differ: %d and %d", b.Length(), b.Inverse().Length())
+			}
+		})
+	}
+}
+
+func TestBiMapForcePut(t *testing.T) {
+	b := bimap.New[string, int]()
+	b.Put("a", 1)
+	b.Put("b", 2)
+	b.ForcePut("a", 2)
+	if b.Length() != 1 {
+		t.Errorf("Expected length 1, got %d", b.Length())
+	}
+	if value, ok := b.Get("a"); !ok || value != 2 {
+		t.Errorf("Expected a to map to 2, got %d", value)
+	}
+	if b.ContainsKey("b") || b.ContainsValue(1) {
+		t.Error("Expected stale mappings to be removed")
+	}
+}
+
+func TestBiMapInverse(t *testing.T) {
+	b := bimap.New[string, int]()
+	b.Put("a", 1)
+	inverse := b.Inverse()
+	if key, ok := inverse.Get(1); !ok || key != "a" {
+		t.Errorf("Expected 1 to map to a, got %q", key)
+	}
+	inverse.Put(2, "b")
+	if value, ok := b.Get("b"); !ok || value != 2 {
+		t.Errorf("Expected changes to the inverse to be visible, got %d", value)
+	}
+	if key, ok := b.RemoveValue(1); !ok || key != "a" {
+		t.Errorf("Expected RemoveValue to return a, got %q", key)
+	}
+	if inverse.ContainsKey(1) {
+		t.Error("Expected removal to be visible in the inverse")
+	}
+	if _, ok := b.Remove("missing"); ok {
+		t.Error("Expected Remove to return false for missing key")
+	}
+}
+
+func TestBiMapRange(t *testing.T) {
+	b := bimap.New[int, int]()
+	for i := 0; i < 10; i++ {
+		b.Put(i, i*10)
+	}
+	count := 0
+	for entry := range b.Range() {
+		if entry.Value != entry.Key*10 {
+			t.Errorf("Unexpected entry %v", entry)
+		}
+		count++
+	}
+	if count != 10 {
+		t.Errorf("Expected 10 entries, got %d", count)
+	}
+}
+
+func TestBiMapConcurrency(t *testing.T) {
+	b := bimap.New[int, int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b.ForcePut(i%10, i%10)
+			b.Inverse().GetKey(i % 10)
+		}(i)
+	}
+	wg.Wait()
+	if b.Length() != 10 {
+		t.Errorf("Expected length 10, got %d", b.Length())
+	}
+}
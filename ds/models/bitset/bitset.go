@@ -0,0 +1,443 @@
+// Description: This package contains the implementation of a growable bitset.
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"sync"
+)
+
+// ErrInvalidData is returned when unmarshaling data that does not hold a bitset.
+var ErrInvalidData = errors.New("bitset: invalid data")
+
+// magic identifies the binary format of a bitset.
+var magic = [4]byte{'G', 'B', 'S', '1'}
+
+// headerSize is the size of the magic and length in the binary format.
+const headerSize = 4 + 8
+
+// The BitSet struct represents a set of non-negative integers stored one bit each.
+// It grows as bits are set; bits beyond its length read as clear.
+type BitSet struct {
+	words  []uint64
+	length uint
+	mu     sync.RWMutex
+}
+
+// New creates a new bitset of the given length with every bit clear.
+func New(length uint) *BitSet {
+	return &BitSet{
+		words:  make([]uint64, wordsFor(length)),
+		length: length,
+	}
+}
+
+// FromIndices creates a new bitset with the given bits set.
+func FromIndices(indices ...uint) *BitSet {
+	b := New(0)
+	for _, i := range indices {
+		b.set(i)
+	}
+	return b
+}
+
+// Unmarshal creates a new bitset from data produced by MarshalBinary.
+func Unmarshal(data []byte) (*BitSet, error) {
+	b := &BitSet{}
+	if err := b.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// wordsFor returns the number of words needed to hold length bits.
+func wordsFor(length uint) int {
+	return int((length + 63) / 64)
+}
+
+// grow extends the bitset to hold at least length bits.
+func (b *BitSet) grow(length uint) {
+	if length <= b.length {
+		return
+	}
+	if n := wordsFor(length); n > len(b.words) {
+		if n <= cap(b.words) {
+			b.words = b.words[:n]
+		} else {
+			words := make([]uint64, n, max(n, 2*cap(b.words)))
+			copy(words, b.words)
+			b.words = words
+		}
+	}
+	b.length = length
+}
+
+// set sets bit i.
+func (b *BitSet) set(i uint) {
+	b.grow(i + 1)
+	b.words[i/64] |= 1 << (i % 64)
+}
+
+// test reports whether bit i is set.
+func (b *BitSet) test(i uint) bool {
+	return i < b.length && b.words[i/64]&(1<<(i%64)) != 0
+}
+
+// apply calls fn with each word overlapping bits [from, to) and the mask of the bits in range.
+func (b *BitSet) apply(from, to uint, fn func(word *uint64, mask uint64)) {
+	for from < to {
+		w := from / 64
+		mask := ^uint64(0) << (from % 64)
+		end := (w + 1) * 64
+		if to < end {
+			mask &= ^uint64(0) >> (end - to)
+			end = to
+		}
+		fn(&b.words[w], mask)
+		from = end
+	}
+}
+
+// count returns the number of set bits.
+func (b *BitSet) count() int {
+	count := 0
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// nextSet returns the index of the first set bit at or after i.
+func (b *BitSet) nextSet(i uint) (uint, bool) {
+	if i >= b.length {
+		return 0, false
+	}
+	w := int(i / 64)
+	word := b.words[w] >> (i % 64)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return uint(w)*64 + uint(bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return 0, false
+}
+
+// nextClear returns the index of the first clear bit at or after i.
+func (b *BitSet) nextClear(i uint) uint {
+	if i >= b.length {
+		return i
+	}
+	w := int(i / 64)
+	word := ^b.words[w] >> (i % 64)
+	if word != 0 {
+		return min(i+uint(bits.TrailingZeros64(word)), b.length)
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != ^uint64(0) {
+			return min(uint(w)*64+uint(bits.TrailingZeros64(^b.words[w])), b.length)
+		}
+	}
+	return b.length
+}
+
+// clone returns a copy of the bitset.
+func (b *BitSet) clone() *BitSet {
+	return &BitSet{
+		words:  append([]uint64(nil), b.words...),
+		length: b.length,
+	}
+}
+
+// snapshot returns a copy of the bitset in a concurrency-safe manner.
+func (b *BitSet) snapshot() *BitSet {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.clone()
+}
+
+// combine replaces the bits of b with op applied to the bits of b and other in a
+// concurrency-safe manner, treating missing words as zero. The bits of other are copied
+// first so that the two locks are never held together.
+func (b *BitSet) combine(other *BitSet, op func(a, c uint64) uint64) {
+	o := other.snapshot()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.grow(o.length)
+	for i := range b.words {
+		var word uint64
+		if i < len(o.words) {
+			word = o.words[i]
+		}
+		b.words[i] = op(b.words[i], word)
+	}
+}
+
+// Set sets bit i in a concurrency-safe manner, growing the bitset if needed.
+func (b *BitSet) Set(i uint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.set(i)
+}
+
+// Clear clears bit i in a concurrency-safe manner.
+func (b *BitSet) Clear(i uint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if i < b.length {
+		b.words[i/64] &^= 1 << (i % 64)
+	}
+}
+
+// Flip toggles bit i in a concurrency-safe manner, growing the bitset if needed.
+func (b *BitSet) Flip(i uint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.grow(i + 1)
+	b.words[i/64] ^= 1 << (i % 64)
+}
+
+// Test returns true if bit i is set, in a concurrency-safe manner.
+func (b *BitSet) Test(i uint) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.test(i)
+}
+
+// SetRange sets the bits in [from, to) in a concurrency-safe manner, growing the bitset if needed.
+func (b *BitSet) SetRange(from, to uint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if from >= to {
+		return
+	}
+	b.grow(to)
+	b.apply(from, to, func(word *uint64, mask uint64) { *word |= mask })
+}
+
+// ClearRange clears the bits in [from, to) in a concurrency-safe manner.
+func (b *BitSet) ClearRange(from, to uint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	to = min(to, b.length)
+	b.apply(from, to, func(word *uint64, mask uint64) { *word &^= mask })
+}
+
+// FlipRange toggles the bits in [from, to) in a concurrency-safe manner, growing the bitset if needed.
+func (b *BitSet) FlipRange(from, to uint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if from >= to {
+		return
+	}
+	b.grow(to)
+	b.apply(from, to, func(word *uint64, mask uint64) { *word ^= mask })
+}
+
+// ClearAll clears every bit in a concurrency-safe manner. The length is kept.
+func (b *BitSet) ClearAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clear(b.words)
+}
+
+// Count returns the number of set bits in a concurrency-safe manner.
+func (b *BitSet) Count() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.count()
+}
+
+// CountRange returns the number of set bits in [from, to) in a concurrency-safe manner.
+func (b *BitSet) CountRange(from, to uint) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	count := 0
+	b.apply(from, min(to, b.length), func(word *uint64, mask uint64) {
+		count += bits.OnesCount64(*word & mask)
+	})
+	return count
+}
+
+// Length returns the number of bits the bitset has grown to in a concurrency-safe manner.
+func (b *BitSet) Length() uint {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.length
+}
+
+// IsEmpty returns true if no bit is set, in a concurrency-safe manner.
+func (b *BitSet) IsEmpty() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, word := range b.words {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NextSet returns the index of the first set bit at or after i in a concurrency-safe manner.
+// It returns false if there is none.
+func (b *BitSet) NextSet(i uint) (uint, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.nextSet(i)
+}
+
+// NextClear returns the index of the first clear bit at or after i in a concurrency-safe manner.
+// Since bits beyond the length are clear, there always is one.
+func (b *BitSet) NextClear(i uint) uint {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.nextClear(i)
+}
+
+// Range returns a channel that iterates over the indices of the set bits in ascending order
+// in a concurrency-safe manner.
+func (b *BitSet) Range() <-chan uint {
+	b.mu.RLock()
+	ch := make(chan uint)
+	go func() {
+		defer b.mu.RUnlock()
+		for w, word := range b.words {
+			for word != 0 {
+				ch <- uint(w)*64 + uint(bits.TrailingZeros64(word))
+				word &= word - 1
+			}
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Indices returns the indices of the set bits in ascending order in a concurrency-safe manner.
+func (b *BitSet) Indices() []uint {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	indices := make([]uint, 0, b.count())
+	for w, word := range b.words {
+		for word != 0 {
+			indices = append(indices, uint(w)*64+uint(bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+	return indices
+}
+
+// Clone returns a copy of the bitset in a concurrency-safe manner.
+func (b *BitSet) Clone() *BitSet {
+	return b.snapshot()
+}
+
+// Equal returns true if both bitsets have the same bits set, in a concurrency-safe manner.
+// Lengths are ignored.
+func (b *BitSet) Equal(other *BitSet) bool {
+	o := other.snapshot()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for i := 0; i < max(len(b.words), len(o.words)); i++ {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(o.words) {
+			y = o.words[i]
+		}
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
+
+// InPlaceAnd keeps in b only the bits also set in other in a concurrency-safe manner.
+func (b *BitSet) InPlaceAnd(other *BitSet) {
+	b.combine(other, func(a, c uint64) uint64 { return a & c })
+}
+
+// InPlaceOr sets in b every bit set in other in a concurrency-safe manner.
+func (b *BitSet) InPlaceOr(other *BitSet) {
+	b.combine(other, func(a, c uint64) uint64 { return a | c })
+}
+
+// InPlaceXor toggles in b every bit set in other in a concurrency-safe manner.
+func (b *BitSet) InPlaceXor(other *BitSet) {
+	b.combine(other, func(a, c uint64) uint64 { return a ^ c })
+}
+
+// InPlaceAndNot clears in b every bit set in other in a concurrency-safe manner.
+func (b *BitSet) InPlaceAndNot(other *BitSet) {
+	b.combine(other, func(a, c uint64) uint64 { return a &^ c })
+}
+
+// And returns a new bitset holding the bits set in both b and other, in a concurrency-safe manner.
+func (b *BitSet) And(other *BitSet) *BitSet {
+	result := b.snapshot()
+	result.InPlaceAnd(other)
+	return result
+}
+
+// Or returns a new bitset holding the bits set in b or other, in a concurrency-safe manner.
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	result := b.snapshot()
+	result.InPlaceOr(other)
+	return result
+}
+
+// Xor returns a new bitset holding the bits set in exactly one of b and other, in a concurrency-safe manner.
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	result := b.snapshot()
+	result.InPlaceXor(other)
+	return result
+}
+
+// AndNot returns a new bitset holding the bits set in b but not in other, in a concurrency-safe manner.
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	result := b.snapshot()
+	result.InPlaceAndNot(other)
+	return result
+}
+
+// MarshalBinary encodes the bitset in a concurrency-safe manner.
+//
+// The format is the magic "GBS1", the length as a little-endian uint64 and then the
+// ceil(length/64) words as little-endian uint64s, lowest bits first.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	data := make([]byte, headerSize, headerSize+8*len(b.words))
+	copy(data, magic[:])
+	binary.LittleEndian.PutUint64(data[4:], uint64(b.length))
+	for _, word := range b.words {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the bitset with data produced by MarshalBinary,
+// in a concurrency-safe manner.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || [4]byte(data[:4]) != magic {
+		return ErrInvalidData
+	}
+	length := binary.LittleEndian.Uint64(data[4:])
+	if length > uint64(len(data))*8 || uint64(len(data)-headerSize) != (length+63)/64*8 {
+		return ErrInvalidData
+	}
+	words := make([]uint64, (length+63)/64)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[headerSize+8*i:])
+	}
+	if length%64 != 0 && len(words) > 0 && words[len(words)-1]>>(length%64) != 0 {
+		return ErrInvalidData
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.words, b.length = words, uint(length)
+	return nil
+}
@@ -0,0 +1,194 @@
+package bitset_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/bitset"
+)
+
+func TestBitSetSetClearFlip(t *testing.T) {
+	b := bitset.New(10)
+	b.Set(3)
+	b.Set(130)
+	b.Flip(7)
+	b.Flip(3)
+	b.Clear(500)
+
+	if b.Length() != 131 {
+		t.Errorf("Expected length 131, got %d", b.Length())
+	}
+	for i, expected := range map[uint]bool{3: false, 7: true, 130: true, 131: false, 10000: false} {
+		if b.Test(i) != expected {
+			t.Errorf("Expected bit %d to be %t", i, expected)
+		}
+	}
+	if b.Count() != 2 {
+		t.Errorf("Expected 2 set bits, got %d", b.Count())
+	}
+	b.ClearAll()
+	if !b.IsEmpty() || b.Length() != 131 {
+		t.Error("Expected ClearAll to clear every bit and keep the length")
+	}
+}
+
+func TestBitSetRanges(t *testing.T) {
+	b := bitset.New(0)
+	b.SetRange(60, 200)
+	if b.Count() != 140 || b.Length() != 200 {
+		t.Fatalf("Expected 140 set bits over 200, got %d over %d", b.Count(), b.Length())
+	}
+	b.ClearRange(64, 128)
+	b.FlipRange(190, 210)
+	tests := []struct {
+		from, to uint
+		expected int
+	}{
+		{from: 0, to: 64, expected: 4},
+		{from: 64, to: 128, expected: 0},
+		{from: 128, to: 190, expected: 62},
+		{from: 190, to: 200, expected: 0},
+		{from: 200, to: 210, expected: 10},
+		{from: 0, to: 1000, expected: 76},
+		{from: 5, to: 5, expected: 0},
+	}
+	for _, test := range tests {
+		if count := b.CountRange(test.from, test.to); count != test.expected {
+			t.Errorf("Expected %d set bits in [%d, %d), got %d", test.expected, test.from, test.to, count)
+		}
+	}
+}
+
+func TestBitSetNext(t *testing.T) {
+	b := bitset.FromIndices(0, 1, 2, 64, 65, 300)
+	var set []uint
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		set = append(set, i)
+	}
+	if !slices.Equal(set, []uint{0, 1, 2, 64, 65, 300}) {
+		t.Errorf("Expected NextSet to visit every set bit, got %v", set)
+	}
+	if _, ok := b.NextSet(301); ok {
+		t.Error("Expected no set bit past 300")
+	}
+	tests := []struct {
+		from, expected uint
+	}{
+		{from: 0, expected: 3},
+		{from: 64, expected: 66},
+		{from: 300, expected: 301},
+		{from: 1000, expected: 1000},
+	}
+	for _, test := range tests {
+		if next := b.NextClear(test.from); next != test.expected {
+			t.Errorf("Expected NextClear(%d) = %d, got %d", test.from, test.expected, next)
+		}
+	}
+	full := bitset.New(0)
+	full.SetRange(0, 128)
+	if next := full.NextClear(0); next != 128 {
+		t.Errorf("Expected NextClear of a full bitset to return its length, got %d", next)
+	}
+}
+
+func TestBitSetOperations(t *testing.T) {
+	a := bitset.FromIndices(1, 2, 3, 100)
+	b := bitset.FromIndices(2, 3, 4, 200)
+	tests := []struct {
+		name     string
+		result   *bitset.BitSet
+		expected []uint
+	}{
+		{name: "And", result: a.And(b), expected: []uint{2, 3}},
+		{name: "Or", result: a.Or(b), expected: []uint{1, 2, 3, 4, 100, 200}},
+		{name: "Xor", result: a.Xor(b), expected: []uint{1, 4, 100, 200}},
+		{name: "AndNot", result: a.AndNot(b), expected: []uint{1, 100}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if indices := test.result.Indices(); !slices.Equal(indices, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, indices)
+			}
+		})
+	}
+	if !slices.Equal(a.Indices(), []uint{1, 2, 3, 100}) {
+		t.Error("Expected out-of-place operations to leave the operands unchanged")
+	}
+
+	c := a.Clone()
+	c.InPlaceOr(b)
+	c.InPlaceAndNot(bitset.FromIndices(200))
+	c.InPlaceXor(bitset.FromIndices(1, 5))
+	c.InPlaceAnd(c)
+	if !c.Equal(bitset.FromIndices(2, 3, 4, 5, 100)) {
+		t.Errorf("Expected in-place operations to give [2 3 4 5 100], got %v", c.Indices())
+	}
+}
+
+func TestBitSetRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	b := bitset.New(0)
+	reference := make(map[uint]bool)
+	for n := 0; n < 5000; n++ {
+		i := uint(rng.Intn(1000))
+		switch rng.Intn(3) {
+		case 0:
+			b.Set(i)
+			reference[i] = true
+		case 1:
+			b.Clear(i)
+			delete(reference, i)
+		case 2:
+			b.Flip(i)
+			if reference[i] {
+				delete(reference, i)
+			} else {
+				reference[i] = true
+			}
+		}
+	}
+	if b.Count() != len(reference) {
+		t.Fatalf("Expected %d set bits, got %d", len(reference), b.Count())
+	}
+	var previous uint
+	first := true
+	for i := range b.Range() {
+		if !reference[i] || (!first && i <= previous) {
+			t.Fatalf("Unexpected bit %d from Range", i)
+		}
+		previous, first = i, false
+	}
+}
+
+func TestBitSetMarshal(t *testing.T) {
+	b := bitset.FromIndices(0, 65)
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := []byte{
+		'G', 'B', 'S', '1', 66, 0, 0, 0, 0, 0, 0, 0,
+		1, 0, 0, 0, 0, 0, 0, 0,
+		2, 0, 0, 0, 0, 0, 0, 0,
+	}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Expected encoding %v, got %v", expected, data)
+	}
+	loaded, err := bitset.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !loaded.Equal(b) || loaded.Length() != 66 {
+		t.Error("Expected the bitset to survive a round trip")
+	}
+	if _, err := bitset.Unmarshal(data[:len(data)-1]); !errors.Is(err, bitset.ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for truncated data, got %v", err)
+	}
+	data[len(data)-8] = 4
+	if _, err := bitset.Unmarshal(data); !errors.Is(err, bitset.ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for a bit beyond the length, got %v", err)
+	}
+}
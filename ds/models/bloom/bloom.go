@@ -0,0 +1,239 @@
+// Description: This package contains the implementation of a Bloom filter.
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/mmygods/gods/ds/hashing"
+)
+
+var (
+	// ErrIncompatible is returned when combining filters of different sizes or hash counts.
+	ErrIncompatible = errors.New("bloom: filters are not compatible")
+	// ErrInvalidData is returned when unmarshaling data that does not hold a filter.
+	ErrInvalidData = errors.New("bloom: invalid data")
+)
+
+// magic identifies the binary format of a filter.
+var magic = [4]byte{'G', 'B', 'F', '1'}
+
+// headerSize is the size of the magic, bit count and hash count in the binary format.
+const headerSize = 4 + 8 + 4
+
+// The Filter struct represents a Bloom filter over keys of type T.
+// Filters only answer MayContain correctly for keys hashed with the same Hasher.
+type Filter[T any] struct {
+	words []uint64
+	m     uint64
+	k     uint32
+	hash  hashing.Hasher[T]
+	mu    sync.RWMutex
+}
+
+// New creates a new filter sized to hold n keys with a false-positive rate of at most p.
+func New[T any](n int, p float64, hash hashing.Hasher[T]) *Filter[T] {
+	m, k := OptimalSize(n, p)
+	return NewWithSize(m, k, hash)
+}
+
+// NewWithSize creates a new filter of m bits using k hash functions.
+func NewWithSize[T any](m uint64, k uint32, hash hashing.Hasher[T]) *Filter[T] {
+	if m == 0 {
+		m = 1
+	}
+	if k == 0 {
+		k = 1
+	}
+	return &Filter[T]{
+		words: make([]uint64, (m+63)/64),
+		m:     m,
+		k:     k,
+		hash:  hash,
+	}
+}
+
+// OptimalSize returns the number of bits and hash functions minimizing memory for n keys
+// at a false-positive rate of p.
+func OptimalSize(n int, p float64) (uint64, uint32) {
+	if n < 1 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = 0.01
+	}
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return uint64(m), uint32(math.Max(k, 1))
+}
+
+// Unmarshal creates a new filter from data produced by MarshalBinary.
+func Unmarshal[T any](data []byte, hash hashing.Hasher[T]) (*Filter[T], error) {
+	f := &Filter[T]{hash: hash}
+	if err := f.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// locations calls fn with each of the k bit positions of the key, using double hashing.
+func (f *Filter[T]) locations(key T, fn func(bit uint64) bool) bool {
+	h1 := f.hash(key)
+	h2 := hashing.Mix64(h1) | 1
+	for i := uint32(0); i < f.k; i++ {
+		if !fn((h1 + uint64(i)*h2) % f.m) {
+			return false
+		}
+	}
+	return true
+}
+
+// add sets the bits of the key.
+func (f *Filter[T]) add(key T) {
+	f.locations(key, func(bit uint64) bool {
+		f.words[bit/64] |= 1 << (bit % 64)
+		return true
+	})
+}
+
+// mayContain reports whether all bits of the key are set.
+func (f *Filter[T]) mayContain(key T) bool {
+	return f.locations(key, func(bit uint64) bool {
+		return f.words[bit/64]&(1<<(bit%64)) != 0
+	})
+}
+
+// bitCount returns the number of set bits.
+func (f *Filter[T]) bitCount() int {
+	count := 0
+	for _, word := range f.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// combine replaces the bits of f with op applied to the bits of f and other.
+// The bits of other are copied first so that the two locks are never held together.
+func (f *Filter[T]) combine(other *Filter[T], op func(a, b uint64) uint64) error {
+	other.mu.RLock()
+	m, k := other.m, other.k
+	words := append([]uint64(nil), other.words...)
+	other.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.m != m || f.k != k {
+		return ErrIncompatible
+	}
+	for i := range f.words {
+		f.words[i] = op(f.words[i], words[i])
+	}
+	return nil
+}
+
+// Add adds the key to the filter in a concurrency-safe manner.
+func (f *Filter[T]) Add(key T) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.add(key)
+}
+
+// MayContain returns false if the key was definitely never added, in a concurrency-safe manner.
+// A true result may be a false positive.
+func (f *Filter[T]) MayContain(key T) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.mayContain(key)
+}
+
+// Union adds every key of other to f in a concurrency-safe manner.
+// Both filters must have the same size and hash count.
+func (f *Filter[T]) Union(other *Filter[T]) error {
+	return f.combine(other, func(a, b uint64) uint64 { return a | b })
+}
+
+// Intersect keeps in f only the bits also set in other in a concurrency-safe manner.
+// Both filters must have the same size and hash count. The result may report more
+// false positives than a filter built from the intersection of the key sets.
+func (f *Filter[T]) Intersect(other *Filter[T]) error {
+	return f.combine(other, func(a, b uint64) uint64 { return a & b })
+}
+
+// EstimatedCount returns an estimate of the number of distinct keys added, in a concurrency-safe manner.
+func (f *Filter[T]) EstimatedCount() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	set := float64(f.bitCount())
+	m := float64(f.m)
+	if set >= m {
+		return int(m / float64(f.k))
+	}
+	return int(math.Round(-m / float64(f.k) * math.Log(1-set/m)))
+}
+
+// FalsePositiveRate returns the current probability of a false positive, in a concurrency-safe manner.
+func (f *Filter[T]) FalsePositiveRate() float64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return math.Pow(float64(f.bitCount())/float64(f.m), float64(f.k))
+}
+
+// Size returns the number of bits in the filter in a concurrency-safe manner.
+func (f *Filter[T]) Size() uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.m
+}
+
+// HashCount returns the number of hash functions used per key in a concurrency-safe manner.
+func (f *Filter[T]) HashCount() uint32 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.k
+}
+
+// Clear removes every key from the filter in a concurrency-safe manner.
+func (f *Filter[T]) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	clear(f.words)
+}
+
+// MarshalBinary encodes the filter in a concurrency-safe manner. The hash function is not
+// encoded; the filter must be unmarshaled with the same Hasher.
+func (f *Filter[T]) MarshalBinary() ([]byte, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	data := make([]byte, headerSize, headerSize+8*len(f.words))
+	copy(data, magic[:])
+	binary.LittleEndian.PutUint64(data[4:], f.m)
+	binary.LittleEndian.PutUint32(data[12:], f.k)
+	for _, word := range f.words {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the filter with data produced by MarshalBinary,
+// in a concurrency-safe manner. The hash function of the filter is kept.
+func (f *Filter[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || [4]byte(data[:4]) != magic {
+		return ErrInvalidData
+	}
+	m := binary.LittleEndian.Uint64(data[4:])
+	k := binary.LittleEndian.Uint32(data[12:])
+	if m == 0 || k == 0 || m > uint64(len(data))*8 || uint64(len(data)-headerSize) != (m+63)/64*8 {
+		return ErrInvalidData
+	}
+	words := make([]uint64, (m+63)/64)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[headerSize+8*i:])
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.words, f.m, f.k = words, m, k
+	return nil
+}
@@ -0,0 +1,127 @@
+package bloom_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mmygods/gods/ds/hashing"
+	"github.com/mmygods/gods/ds/models/bloom"
+)
+
+func TestBloomNoFalseNegatives(t *testing.T) {
+	f := bloom.New(1000, 0.01, hashing.String)
+	for i := 0; i < 1000; i++ {
+		f.Add(fmt.Sprint("key-", i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !f.MayContain(fmt.Sprint("key-", i)) {
+			t.Fatalf("False negative for key-%d", i)
+		}
+	}
+}
+
+func TestBloomFalsePositiveRate(t *testing.T) {
+	tests := []struct {
+		name string
+		p    float64
+	}{
+		{name: "One percent", p: 0.01},
+		{name: "One per mille", p: 0.001},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := bloom.New(10000, test.p, hashing.Int)
+			for i := 0; i < 10000; i++ {
+				f.Add(i)
+			}
+			positives := 0
+			for i := 10000; i < 110000; i++ {
+				if f.MayContain(i) {
+					positives++
+				}
+			}
+			if rate := float64(positives) / 100000; rate > 2*test.p {
+				t.Errorf("Expected false-positive rate near %g, got %g", test.p, rate)
+			}
+		})
+	}
+}
+
+func TestBloomEstimatedCount(t *testing.T) {
+	f := bloom.New(10000, 0.01, hashing.Int)
+	for i := 0; i < 5000; i++ {
+		f.Add(i)
+		f.Add(i)
+	}
+	if estimate := f.EstimatedCount(); math.Abs(float64(estimate)-5000) > 250 {
+		t.Errorf("Expected an estimate near 5000, got %d", estimate)
+	}
+}
+
+func TestBloomUnionIntersect(t *testing.T) {
+	a := bloom.New(1000, 0.01, hashing.String)
+	b := bloom.New(1000, 0.01, hashing.String)
+	a.Add("a")
+	a.Add("shared")
+	b.Add("b")
+	b.Add("shared")
+
+	union := bloom.New(1000, 0.01, hashing.String)
+	union.Union(a)
+	if err := union.Union(b); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !union.MayContain("a") || !union.MayContain("b") || !union.MayContain("shared") {
+		t.Error("Expected union to contain keys of both filters")
+	}
+	if err := a.Intersect(b); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !a.MayContain("shared") {
+		t.Error("Expected intersection to contain the shared key")
+	}
+	if err := a.Union(bloom.New(10, 0.01, hashing.String)); !errors.Is(err, bloom.ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestBloomMarshal(t *testing.T) {
+	f := bloom.New(500, 0.01, hashing.String)
+	for i := 0; i < 500; i++ {
+		f.Add(fmt.Sprint(i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "filter.bin")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	read, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := bloom.Unmarshal(read, hashing.String)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if loaded.Size() != f.Size() || loaded.HashCount() != f.HashCount() {
+		t.Error("Expected size and hash count to survive a round trip")
+	}
+	for i := 0; i < 500; i++ {
+		if !loaded.MayContain(fmt.Sprint(i)) {
+			t.Fatalf("Loaded filter lost key %d", i)
+		}
+	}
+	if _, err := bloom.Unmarshal(read[:len(read)-1], hashing.String); !errors.Is(err, bloom.ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for truncated data, got %v", err)
+	}
+	if _, err := bloom.Unmarshal([]byte("junk"), hashing.String); !errors.Is(err, bloom.ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for junk, got %v", err)
+	}
+}
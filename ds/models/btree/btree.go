@@ -0,0 +1,535 @@
+// Description: This package contains the implementation of an in-memory B-tree ordered map.
+package btree
+
+import (
+	"cmp"
+	"sort"
+	"sync"
+)
+
+// DefaultDegree is a degree that works well for most key and value sizes.
+const DefaultDegree = 32
+
+// Entry represents a single key-value pair stored in the tree.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// copyOnWrite identifies the tree that owns a node. Nodes owned by another
+// tree are shared and must be copied before they are modified.
+type copyOnWrite struct {
+	_ byte
+}
+
+// btreeNode represents a node in the tree. Leaves have no children; inner
+// nodes have exactly one more child than entries.
+type btreeNode[K any, V any] struct {
+	entries  []Entry[K, V]
+	children []*btreeNode[K, V]
+	cow      *copyOnWrite
+}
+
+// The BTree struct represents an ordered map stored in a B-tree.
+type BTree[K any, V any] struct {
+	degree  int
+	compare func(a, b K) int
+	root    *btreeNode[K, V]
+	length  int
+	cow     *copyOnWrite
+	mu      sync.RWMutex
+}
+
+// removal selects what remove takes out of the tree.
+type removal int
+
+const (
+	removeKey removal = iota
+	removeMin
+	removeMax
+)
+
+func zeroValue[T any]() T {
+	var zero T
+	return zero
+}
+
+// New creates a new B-tree of the given degree ordering keys naturally.
+// Every node other than the root holds between degree-1 and 2*degree-1 entries.
+func New[K cmp.Ordered, V any](degree int) *BTree[K, V] {
+	return NewWithCompare[K, V](degree, cmp.Compare[K])
+}
+
+// NewWithCompare creates a new B-tree of the given degree ordering keys with compare,
+// which returns a negative number, zero or a positive number when a < b, a == b or a > b.
+func NewWithCompare[K any, V any](degree int, compare func(a, b K) int) *BTree[K, V] {
+	if degree < 2 {
+		panic("btree: degree must be at least 2")
+	}
+	return &BTree[K, V]{
+		degree:  degree,
+		compare: compare,
+		cow:     &copyOnWrite{},
+	}
+}
+
+// maxEntries returns the maximum number of entries in a node.
+func (t *BTree[K, V]) maxEntries() int {
+	return 2*t.degree - 1
+}
+
+// minEntries returns the minimum number of entries in a non-root node.
+func (t *BTree[K, V]) minEntries() int {
+	return t.degree - 1
+}
+
+// find returns the index of the first entry not less than key, and whether it equals key.
+func (n *btreeNode[K, V]) find(key K, compare func(a, b K) int) (int, bool) {
+	i := sort.Search(len(n.entries), func(i int) bool {
+		return compare(n.entries[i].Key, key) >= 0
+	})
+	return i, i < len(n.entries) && compare(n.entries[i].Key, key) == 0
+}
+
+// mutableFor returns n if it is owned by cow, or a copy of n owned by cow.
+func (n *btreeNode[K, V]) mutableFor(cow *copyOnWrite) *btreeNode[K, V] {
+	if n.cow == cow {
+		return n
+	}
+	out := &btreeNode[K, V]{cow: cow}
+	out.entries = append(make([]Entry[K, V], 0, cap(n.entries)), n.entries...)
+	if len(n.children) > 0 {
+		out.children = append(make([]*btreeNode[K, V], 0, cap(n.children)), n.children...)
+	}
+	return out
+}
+
+// mutableChild makes the child at index i writable and returns it.
+func (n *btreeNode[K, V]) mutableChild(i int) *btreeNode[K, V] {
+	child := n.children[i].mutableFor(n.cow)
+	n.children[i] = child
+	return child
+}
+
+// split splits n at index i, returning the entry at i and a new node holding everything after it.
+func (n *btreeNode[K, V]) split(i int) (Entry[K, V], *btreeNode[K, V]) {
+	entry := n.entries[i]
+	next := &btreeNode[K, V]{cow: n.cow}
+	next.entries = append(next.entries, n.entries[i+1:]...)
+	clear(n.entries[i:])
+	n.entries = n.entries[:i]
+	if len(n.children) > 0 {
+		next.children = append(next.children, n.children[i+1:]...)
+		clear(n.children[i+1:])
+		n.children = n.children[:i+1]
+	}
+	return entry, next
+}
+
+// maybeSplitChild splits the child at index i if it is full, and reports whether it did.
+func (n *btreeNode[K, V]) maybeSplitChild(i, maxEntries int) bool {
+	if len(n.children[i].entries) < maxEntries {
+		return false
+	}
+	first := n.mutableChild(i)
+	entry, second := first.split(maxEntries / 2)
+	n.entries = insertAt(n.entries, i, entry)
+	n.children = insertAt(n.children, i+1, second)
+	return true
+}
+
+// insert adds or replaces the entry below n, which must not be full.
+// It returns true if the key was not present.
+func (n *btreeNode[K, V]) insert(entry Entry[K, V], maxEntries int, compare func(a, b K) int) bool {
+	i, found := n.find(entry.Key, compare)
+	if found {
+		n.entries[i] = entry
+		return false
+	}
+	if len(n.children) == 0 {
+		n.entries = insertAt(n.entries, i, entry)
+		return true
+	}
+	if n.maybeSplitChild(i, maxEntries) {
+		switch c := compare(entry.Key, n.entries[i].Key); {
+		case c > 0:
+			i++
+		case c == 0:
+			n.entries[i] = entry
+			return false
+		}
+	}
+	return n.mutableChild(i).insert(entry, maxEntries, compare)
+}
+
+// get returns the entry for key below n.
+func (n *btreeNode[K, V]) get(key K, compare func(a, b K) int) (Entry[K, V], bool) {
+	for n != nil {
+		i, found := n.find(key, compare)
+		if found {
+			return n.entries[i], true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	return Entry[K, V]{}, false
+}
+
+// remove removes an entry below n, making sure every visited child has more than minEntries entries.
+func (n *btreeNode[K, V]) remove(key K, minEntries int, typ removal, compare func(a, b K) int) (Entry[K, V], bool) {
+	var i int
+	var found bool
+	switch typ {
+	case removeMax:
+		if len(n.children) == 0 {
+			entry := n.entries[len(n.entries)-1]
+			n.entries = removeAt(n.entries, len(n.entries)-1)
+			return entry, true
+		}
+		i = len(n.entries)
+	case removeMin:
+		if len(n.children) == 0 {
+			entry := n.entries[0]
+			n.entries = removeAt(n.entries, 0)
+			return entry, true
+		}
+		i = 0
+	case removeKey:
+		i, found = n.find(key, compare)
+		if len(n.children) == 0 {
+			if !found {
+				return Entry[K, V]{}, false
+			}
+			entry := n.entries[i]
+			n.entries = removeAt(n.entries, i)
+			return entry, true
+		}
+	}
+	if len(n.children[i].entries) <= minEntries {
+		return n.growChildAndRemove(i, key, minEntries, typ, compare)
+	}
+	child := n.mutableChild(i)
+	if found {
+		// Replace the entry with its predecessor, the maximum of the left child.
+		entry := n.entries[i]
+		n.entries[i], _ = child.remove(key, minEntries, removeMax, compare)
+		return entry, true
+	}
+	return child.remove(key, minEntries, typ, compare)
+}
+
+// growChildAndRemove gives the child at index i an extra entry, by stealing
+// from a sibling or merging with one, and then retries the removal.
+func (n *btreeNode[K, V]) growChildAndRemove(i int, key K, minEntries int, typ removal, compare func(a, b K) int) (Entry[K, V], bool) {
+	if i > 0 && len(n.children[i-1].entries) > minEntries {
+		child := n.mutableChild(i)
+		left := n.mutableChild(i - 1)
+		stolen := left.entries[len(left.entries)-1]
+		left.entries = removeAt(left.entries, len(left.entries)-1)
+		child.entries = insertAt(child.entries, 0, n.entries[i-1])
+		n.entries[i-1] = stolen
+		if len(left.children) > 0 {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = removeAt(left.children, len(left.children)-1)
+		}
+	} else if i < len(n.entries) && len(n.children[i+1].entries) > minEntries {
+		child := n.mutableChild(i)
+		right := n.mutableChild(i + 1)
+		stolen := right.entries[0]
+		right.entries = removeAt(right.entries, 0)
+		child.entries = append(child.entries, n.entries[i])
+		n.entries[i] = stolen
+		if len(right.children) > 0 {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+	} else {
+		if i >= len(n.entries) {
+			i--
+		}
+		child := n.mutableChild(i)
+		merged := n.children[i+1]
+		child.entries = append(child.entries, n.entries[i])
+		child.entries = append(child.entries, merged.entries...)
+		child.children = append(child.children, merged.children...)
+		n.entries = removeAt(n.entries, i)
+		n.children = removeAt(n.children, i+1)
+	}
+	return n.remove(key, minEntries, typ, compare)
+}
+
+// ascend calls fn for every entry below n with lo <= key < hi, in ascending order.
+// A nil bound is unbounded. It returns false if fn stopped the iteration.
+func (n *btreeNode[K, V]) ascend(lo, hi *K, compare func(a, b K) int, fn func(Entry[K, V]) bool) bool {
+	i := 0
+	if lo != nil {
+		i, _ = n.find(*lo, compare)
+	}
+	for ; i < len(n.entries); i++ {
+		if len(n.children) > 0 && !n.children[i].ascend(lo, hi, compare, fn) {
+			return false
+		}
+		if hi != nil && compare(n.entries[i].Key, *hi) >= 0 {
+			return false
+		}
+		if !fn(n.entries[i]) {
+			return false
+		}
+	}
+	if len(n.children) > 0 {
+		return n.children[len(n.children)-1].ascend(lo, hi, compare, fn)
+	}
+	return true
+}
+
+// descend calls fn for every entry below n with lo < key <= hi, in descending order.
+// A nil bound is unbounded. It returns false if fn stopped the iteration.
+func (n *btreeNode[K, V]) descend(hi, lo *K, compare func(a, b K) int, fn func(Entry[K, V]) bool) bool {
+	i := len(n.entries) - 1
+	if hi != nil {
+		j, found := n.find(*hi, compare)
+		if found {
+			i = j
+		} else {
+			i = j - 1
+		}
+	}
+	if len(n.children) > 0 && !n.children[i+1].descend(hi, lo, compare, fn) {
+		return false
+	}
+	for ; i >= 0; i-- {
+		if lo != nil && compare(n.entries[i].Key, *lo) <= 0 {
+			return false
+		}
+		if !fn(n.entries[i]) {
+			return false
+		}
+		if len(n.children) > 0 && !n.children[i].descend(hi, lo, compare, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// insertAt inserts value into s at index i.
+func insertAt[T any](s []T, i int, value T) []T {
+	s = append(s, zeroValue[T]())
+	copy(s[i+1:], s[i:])
+	s[i] = value
+	return s
+}
+
+// removeAt removes the element of s at index i.
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	s[len(s)-1] = zeroValue[T]()
+	return s[:len(s)-1]
+}
+
+// put adds or replaces the value for the key. It returns true if the key was not present.
+func (t *BTree[K, V]) put(key K, value V) bool {
+	entry := Entry[K, V]{Key: key, Value: value}
+	if t.root == nil {
+		t.root = &btreeNode[K, V]{cow: t.cow}
+		t.root.entries = append(t.root.entries, entry)
+		t.length++
+		return true
+	}
+	t.root = t.root.mutableFor(t.cow)
+	if len(t.root.entries) >= t.maxEntries() {
+		middle, second := t.root.split(t.maxEntries() / 2)
+		oldRoot := t.root
+		t.root = &btreeNode[K, V]{cow: t.cow}
+		t.root.entries = append(t.root.entries, middle)
+		t.root.children = append(t.root.children, oldRoot, second)
+	}
+	added := t.root.insert(entry, t.maxEntries(), t.compare)
+	if added {
+		t.length++
+	}
+	return added
+}
+
+// remove removes an entry of the given kind from the tree.
+func (t *BTree[K, V]) remove(key K, typ removal) (Entry[K, V], bool) {
+	if t.root == nil || len(t.root.entries) == 0 {
+		return Entry[K, V]{}, false
+	}
+	t.root = t.root.mutableFor(t.cow)
+	entry, ok := t.root.remove(key, t.minEntries(), typ, t.compare)
+	if len(t.root.entries) == 0 && len(t.root.children) > 0 {
+		t.root = t.root.children[0]
+	}
+	if ok {
+		t.length--
+	}
+	return entry, ok
+}
+
+// min returns the entry with the smallest key.
+func (t *BTree[K, V]) min() (Entry[K, V], bool) {
+	n := t.root
+	if n == nil || len(n.entries) == 0 {
+		return Entry[K, V]{}, false
+	}
+	for len(n.children) > 0 {
+		n = n.children[0]
+	}
+	return n.entries[0], true
+}
+
+// max returns the entry with the largest key.
+func (t *BTree[K, V]) max() (Entry[K, V], bool) {
+	n := t.root
+	if n == nil || len(n.entries) == 0 {
+		return Entry[K, V]{}, false
+	}
+	for len(n.children) > 0 {
+		n = n.children[len(n.children)-1]
+	}
+	return n.entries[len(n.entries)-1], true
+}
+
+// Put adds or replaces the value for the key in a concurrency-safe manner.
+// It returns true if the key was not already present.
+func (t *BTree[K, V]) Put(key K, value V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.put(key, value)
+}
+
+// Get returns the value for the key in a concurrency-safe manner.
+func (t *BTree[K, V]) Get(key K) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.root == nil {
+		return zeroValue[V](), false
+	}
+	entry, ok := t.root.get(key, t.compare)
+	return entry.Value, ok
+}
+
+// Has returns true if the key is present in a concurrency-safe manner.
+func (t *BTree[K, V]) Has(key K) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Delete removes the key in a concurrency-safe manner.
+func (t *BTree[K, V]) Delete(key K) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.remove(key, removeKey)
+	return ok
+}
+
+// Min returns the entry with the smallest key in a concurrency-safe manner.
+func (t *BTree[K, V]) Min() (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	entry, ok := t.min()
+	return entry.Key, entry.Value, ok
+}
+
+// Max returns the entry with the largest key in a concurrency-safe manner.
+func (t *BTree[K, V]) Max() (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	entry, ok := t.max()
+	return entry.Key, entry.Value, ok
+}
+
+// DeleteMin removes and returns the entry with the smallest key in a concurrency-safe manner.
+func (t *BTree[K, V]) DeleteMin() (K, V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry, ok := t.remove(zeroValue[K](), removeMin)
+	return entry.Key, entry.Value, ok
+}
+
+// DeleteMax removes and returns the entry with the largest key in a concurrency-safe manner.
+func (t *BTree[K, V]) DeleteMax() (K, V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry, ok := t.remove(zeroValue[K](), removeMax)
+	return entry.Key, entry.Value, ok
+}
+
+// Length returns the number of entries in the tree in a concurrency-safe manner.
+func (t *BTree[K, V]) Length() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.length
+}
+
+// IsEmpty returns true if the tree holds no entries in a concurrency-safe manner.
+func (t *BTree[K, V]) IsEmpty() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.length == 0
+}
+
+// Clone returns a copy of the tree in O(1) in a concurrency-safe manner.
+// Both trees share nodes lazily and copy them on first write, so readers
+// may iterate the clone while writers keep modifying the original.
+func (t *BTree[K, V]) Clone() *BTree[K, V] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cow = &copyOnWrite{}
+	return &BTree[K, V]{
+		degree:  t.degree,
+		compare: t.compare,
+		root:    t.root,
+		length:  t.length,
+		cow:     &copyOnWrite{},
+	}
+}
+
+// iterate sends the entries produced by visit to a channel while holding the read lock.
+func (t *BTree[K, V]) iterate(visit func(n *btreeNode[K, V], fn func(Entry[K, V]) bool)) <-chan Entry[K, V] {
+	t.mu.RLock()
+	ch := make(chan Entry[K, V])
+	go func() {
+		defer t.mu.RUnlock()
+		if t.root != nil {
+			visit(t.root, func(entry Entry[K, V]) bool {
+				ch <- entry
+				return true
+			})
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Range returns a channel that iterates over all entries in ascending key order in a concurrency-safe manner.
+func (t *BTree[K, V]) Range() <-chan Entry[K, V] {
+	return t.iterate(func(n *btreeNode[K, V], fn func(Entry[K, V]) bool) {
+		n.ascend(nil, nil, t.compare, fn)
+	})
+}
+
+// RangeDescending returns a channel that iterates over all entries in descending key order in a concurrency-safe manner.
+func (t *BTree[K, V]) RangeDescending() <-chan Entry[K, V] {
+	return t.iterate(func(n *btreeNode[K, V], fn func(Entry[K, V]) bool) {
+		n.descend(nil, nil, t.compare, fn)
+	})
+}
+
+// AscendRange returns a channel that iterates over the entries with from <= key < to
+// in ascending key order in a concurrency-safe manner.
+func (t *BTree[K, V]) AscendRange(from, to K) <-chan Entry[K, V] {
+	return t.iterate(func(n *btreeNode[K, V], fn func(Entry[K, V]) bool) {
+		n.ascend(&from, &to, t.compare, fn)
+	})
+}
+
+// DescendRange returns a channel that iterates over the entries with from >= key > to
+// in descending key order in a concurrency-safe manner.
+func (t *BTree[K, V]) DescendRange(from, to K) <-chan Entry[K, V] {
+	return t.iterate(func(n *btreeNode[K, V], fn func(Entry[K, V]) bool) {
+		n.descend(&from, &to, t.compare, fn)
+	})
+}
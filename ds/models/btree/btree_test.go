@@ -0,0 +1,180 @@
+package btree_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/btree"
+)
+
+func keys(ch <-chan btree.Entry[int, int]) []int {
+	var out []int
+	for entry := range ch {
+		out = append(out, entry.Key)
+	}
+	return out
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBTreeRandomized(t *testing.T) {
+	for _, degree := range []int{2, 3, 8} {
+		tree := btree.New[int, int](degree)
+		reference := map[int]int{}
+		r := rand.New(rand.NewSource(int64(degree)))
+		for i := 0; i < 5000; i++ {
+			key := r.Intn(500)
+			if r.Intn(3) == 0 {
+				_, present := reference[key]
+				if tree.Delete(key) != present {
+					t.Fatalf("degree %d: Delete(%d) disagreed with reference", degree, key)
+				}
+				delete(reference, key)
+			} else {
+				_, present := reference[key]
+				if tree.Put(key, i) == present {
+					t.Fatalf("degree %d: Put(%d) disagreed with reference", degree, key)
+				}
+				reference[key] = i
+			}
+		}
+		if tree.Length() != len(reference) {
+			t.Fatalf("degree %d: expected length %d, got %d", degree, len(reference), tree.Length())
+		}
+		expected := make([]int, 0, len(reference))
+		for key, value := range reference {
+			expected = append(expected, key)
+			if got, ok := tree.Get(key); !ok || got != value {
+				t.Errorf("degree %d: expected %d for key %d, got %d", degree, value, key, got)
+			}
+		}
+		sort.Ints(expected)
+		if got := keys(tree.Range()); !equal(got, expected) {
+			t.Errorf("degree %d: ascending iteration mismatch", degree)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(expected)))
+		if got := keys(tree.RangeDescending()); !equal(got, expected) {
+			t.Errorf("degree %d: descending iteration mismatch", degree)
+		}
+	}
+}
+
+func TestBTreeMinMax(t *testing.T) {
+	tree := btree.New[int, string](2)
+	if _, _, ok := tree.Min(); ok {
+		t.Error("Expected Min of empty tree to return false")
+	}
+	for _, key := range []int{5, 3, 8, 1, 9, 7} {
+		tree.Put(key, "")
+	}
+	if key, _, _ := tree.Min(); key != 1 {
+		t.Errorf("Expected min 1, got %d", key)
+	}
+	if key, _, _ := tree.Max(); key != 9 {
+		t.Errorf("Expected max 9, got %d", key)
+	}
+	for _, expected := range []int{1, 3, 5} {
+		if key, _, ok := tree.DeleteMin(); !ok || key != expected {
+			t.Errorf("Expected DeleteMin to return %d, got %d", expected, key)
+		}
+	}
+	if key, _, ok := tree.DeleteMax(); !ok || key != 9 {
+		t.Errorf("Expected DeleteMax to return 9, got %d", key)
+	}
+	if tree.Length() != 2 {
+		t.Errorf("Expected length 2, got %d", tree.Length())
+	}
+}
+
+func TestBTreeRanges(t *testing.T) {
+	tree := btree.New[int, int](2)
+	for i := 0; i < 20; i += 2 {
+		tree.Put(i, i)
+	}
+	tests := []struct {
+		name     string
+		ch       <-chan btree.Entry[int, int]
+		expected []int
+	}{
+		{
+			name:     "Ascend inclusive start",
+			ch:       tree.AscendRange(4, 10),
+			expected: []int{4, 6, 8},
+		},
+		{
+			name:     "Ascend between keys",
+			ch:       tree.AscendRange(5, 11),
+			expected: []int{6, 8, 10},
+		},
+		{
+			name:     "Descend inclusive start",
+			ch:       tree.DescendRange(10, 4),
+			expected: []int{10, 8, 6},
+		},
+		{
+			name:     "Descend between keys",
+			ch:       tree.DescendRange(11, 5),
+			expected: []int{10, 8, 6},
+		},
+		{
+			name:     "Empty range",
+			ch:       tree.AscendRange(30, 40),
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := keys(test.ch); !equal(got, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestBTreeClone(t *testing.T) {
+	tree := btree.New[int, int](2)
+	for i := 0; i < 100; i++ {
+		tree.Put(i, i)
+	}
+	snapshot := tree.Clone()
+	for i := 0; i < 100; i += 2 {
+		tree.Delete(i)
+	}
+	tree.Put(1, -1)
+	snapshot.Put(200, 200)
+
+	if snapshot.Length() != 101 || tree.Length() != 50 {
+		t.Fatalf("Expected lengths 101 and 50, got %d and %d", snapshot.Length(), tree.Length())
+	}
+	for i := 0; i < 100; i++ {
+		if value, ok := snapshot.Get(i); !ok || value != i {
+			t.Errorf("Expected snapshot to keep %d, got %d", i, value)
+		}
+	}
+	if value, _ := tree.Get(1); value != -1 {
+		t.Errorf("Expected original to see its own write, got %d", value)
+	}
+	if tree.Has(200) {
+		t.Error("Expected write to the clone not to affect the original")
+	}
+}
+
+func TestBTreeInvalidDegree(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected New to panic for degree 1")
+		}
+	}()
+	btree.New[int, int](1)
+}
@@ -0,0 +1,160 @@
+// Description: This package contains the implementation of a count-min sketch and a heavy-hitters tracker.
+package countmin
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/mmygods/gods/ds/hashing"
+)
+
+// ErrIncompatible is returned when merging sketches of different dimensions.
+var ErrIncompatible = errors.New("countmin: sketches are not compatible")
+
+// Config holds the dimensions of a sketch.
+type Config struct {
+	// Width is the number of counters per row.
+	Width int
+	// Depth is the number of rows, each using a different hash function.
+	Depth int
+	// Conservative enables conservative update, which only raises the counters that
+	// determine the estimate. It reduces overestimation but makes Add slightly slower.
+	Conservative bool
+}
+
+// ConfigFor returns the dimensions guaranteeing that an estimate exceeds the true count by at
+// most epsilon times the total count, with probability at least 1-delta. It panics unless
+// epsilon is positive and delta is between 0 and 1 exclusive.
+func ConfigFor(epsilon, delta float64) Config {
+	if !(epsilon > 0) {
+		panic("countmin: epsilon must be positive")
+	}
+	if !(delta > 0 && delta < 1) {
+		panic("countmin: delta must be between 0 and 1 exclusive")
+	}
+	return Config{
+		Width: int(math.Ceil(math.E / epsilon)),
+		Depth: int(math.Ceil(math.Log(1 / delta))),
+	}
+}
+
+// The Sketch struct represents a count-min sketch over keys of type T.
+type Sketch[T any] struct {
+	counters []uint64
+	config   Config
+	total    uint64
+	hash     hashing.Hasher[T]
+	mu       sync.RWMutex
+}
+
+// New creates a new sketch with error epsilon and confidence 1-delta. It panics if ConfigFor
+// does.
+func New[T any](epsilon, delta float64, hash hashing.Hasher[T]) *Sketch[T] {
+	return NewWithConfig(ConfigFor(epsilon, delta), hash)
+}
+
+// NewWithConfig creates a new sketch with the given dimensions.
+func NewWithConfig[T any](config Config, hash hashing.Hasher[T]) *Sketch[T] {
+	if config.Width < 1 {
+		config.Width = 1
+	}
+	if config.Depth < 1 {
+		config.Depth = 1
+	}
+	return &Sketch[T]{
+		counters: make([]uint64, config.Width*config.Depth),
+		config:   config,
+		hash:     hash,
+	}
+}
+
+// cells calls fn with the index of the counter of the key in every row, using double hashing.
+func (s *Sketch[T]) cells(key T, fn func(cell int)) {
+	h1 := s.hash(key)
+	h2 := hashing.Mix64(h1) | 1
+	width := uint64(s.config.Width)
+	for row := 0; row < s.config.Depth; row++ {
+		fn(row*s.config.Width + int((h1+uint64(row)*h2)%width))
+	}
+}
+
+// estimate returns the smallest counter of the key.
+func (s *Sketch[T]) estimate(key T) uint64 {
+	estimate := uint64(math.MaxUint64)
+	s.cells(key, func(cell int) {
+		estimate = min(estimate, s.counters[cell])
+	})
+	return estimate
+}
+
+// add increments the counters of the key by n and returns its new estimate.
+func (s *Sketch[T]) add(key T, n uint64) uint64 {
+	s.total += n
+	if !s.config.Conservative {
+		s.cells(key, func(cell int) {
+			s.counters[cell] += n
+		})
+		return s.estimate(key)
+	}
+	target := s.estimate(key) + n
+	s.cells(key, func(cell int) {
+		s.counters[cell] = max(s.counters[cell], target)
+	})
+	return target
+}
+
+// Add increments the count of the key by n in a concurrency-safe manner and returns its new estimate.
+func (s *Sketch[T]) Add(key T, n uint64) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(key, n)
+}
+
+// Estimate returns an upper bound of the count of the key in a concurrency-safe manner.
+func (s *Sketch[T]) Estimate(key T) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.estimate(key)
+}
+
+// Total returns the sum of all counts added in a concurrency-safe manner.
+func (s *Sketch[T]) Total() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.total
+}
+
+// Config returns the dimensions of the sketch.
+func (s *Sketch[T]) Config() Config {
+	return s.config
+}
+
+// Merge adds the counts of other to s in a concurrency-safe manner, as if every key added
+// to other had been added to s. Both sketches must have the same width and depth and use
+// the same Hasher.
+func (s *Sketch[T]) Merge(other *Sketch[T]) error {
+	if s.config.Width != other.config.Width || s.config.Depth != other.config.Depth {
+		return ErrIncompatible
+	}
+	other.mu.RLock()
+	counters := append([]uint64(nil), other.counters...)
+	total := other.total
+	other.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, count := range counters {
+		s.counters[i] += count
+	}
+	s.total += total
+	return nil
+}
+
+// Clear resets every count to zero in a concurrency-safe manner.
+func (s *Sketch[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.counters)
+	s.total = 0
+}
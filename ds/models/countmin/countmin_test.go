@@ -0,0 +1,135 @@
+package countmin_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mmygods/gods/ds/hashing"
+	"github.com/mmygods/gods/ds/models/countmin"
+)
+
+// zipf returns a skewed stream of keys and their exact counts.
+func zipf(n int) ([]int, map[int]uint64) {
+	r := rand.New(rand.NewSource(1))
+	z := rand.NewZipf(r, 1.2, 1, 10000)
+	stream := make([]int, n)
+	counts := map[int]uint64{}
+	for i := range stream {
+		stream[i] = int(z.Uint64())
+		counts[stream[i]]++
+	}
+	return stream, counts
+}
+
+func TestSketchErrorBound(t *testing.T) {
+	stream, counts := zipf(100000)
+	for _, conservative := range []bool{false, true} {
+		config := countmin.ConfigFor(0.001, 0.01)
+		config.Conservative = conservative
+		s := countmin.NewWithConfig(config, hashing.Int)
+		for _, key := range stream {
+			s.Add(key, 1)
+		}
+		if s.Total() != uint64(len(stream)) {
+			t.Errorf("Expected total %d, got %d", len(stream), s.Total())
+		}
+		bound := uint64(0.001 * float64(len(stream)))
+		violations := 0
+		for key, count := range counts {
+			estimate := s.Estimate(key)
+			if estimate < count {
+				t.Fatalf("conservative=%t: estimate %d of %d is below its count %d", conservative, estimate, key, count)
+			}
+			if estimate-count > bound {
+				violations++
+			}
+		}
+		if float64(violations) > 0.01*float64(len(counts)) {
+			t.Errorf("conservative=%t: %d of %d estimates exceed the error bound", conservative, violations, len(counts))
+		}
+	}
+}
+
+func TestConfigForPanics(t *testing.T) {
+	tests := []struct {
+		epsilon, delta float64
+	}{
+		{0, 0.01},
+		{-0.1, 0.01},
+		{math.NaN(), 0.01},
+		{0.01, 0},
+		{0.01, 1},
+		{0.01, 1.5},
+		{0.01, math.NaN()},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected ConfigFor(%v, %v) to panic", test.epsilon, test.delta)
+				}
+			}()
+			countmin.ConfigFor(test.epsilon, test.delta)
+		}()
+	}
+}
+
+func TestSketchMerge(t *testing.T) {
+	a := countmin.New(0.01, 0.01, hashing.String)
+	b := countmin.New(0.01, 0.01, hashing.String)
+	a.Add("x", 3)
+	b.Add("x", 4)
+	b.Add("y", 1)
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if a.Estimate("x") < 7 || a.Estimate("y") < 1 || a.Total() != 8 {
+		t.Errorf("Unexpected merged estimates %d and %d", a.Estimate("x"), a.Estimate("y"))
+	}
+	other := countmin.New(0.1, 0.01, hashing.String)
+	if err := a.Merge(other); !errors.Is(err, countmin.ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestHeavyHittersTopK(t *testing.T) {
+	stream, counts := zipf(100000)
+	h := countmin.NewHeavyHitters(5, 0.0005, 0.01, hashing.Int)
+	for _, key := range stream {
+		h.Add(key, 1)
+	}
+	top := h.TopK()
+	if len(top) != 5 {
+		t.Fatalf("Expected 5 entries, got %d", len(top))
+	}
+	for i, entry := range top {
+		// Under a Zipf distribution the heaviest keys are the smallest ones.
+		if entry.Key != i {
+			t.Errorf("Expected key %d at rank %d, got %d", i, i, entry.Key)
+		}
+		if entry.Count < counts[entry.Key] {
+			t.Errorf("Count %d of key %d is below its true count %d", entry.Count, entry.Key, counts[entry.Key])
+		}
+	}
+}
+
+func TestHeavyHittersMerge(t *testing.T) {
+	a := countmin.NewHeavyHitters(2, 0.01, 0.01, hashing.String)
+	b := countmin.NewHeavyHitters(2, 0.01, 0.01, hashing.String)
+	a.Add("a", 10)
+	a.Add("b", 6)
+	b.Add("c", 8)
+	b.Add("b", 5)
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	top := a.TopK()
+	if len(top) != 2 || top[0].Key != "b" || top[1].Key != "a" {
+		t.Errorf("Expected [b a], got %v", top)
+	}
+	if countmin.NewHeavyHitters(0, 0.01, 0.01, hashing.String).Add("x", 1) != 1 {
+		t.Error("Expected a tracker of zero keys to still count")
+	}
+}
@@ -0,0 +1,138 @@
+package countmin
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/mmygods/gods/ds/hashing"
+	"github.com/mmygods/gods/ds/models/heap"
+)
+
+// Entry represents a key and its estimated count.
+type Entry[T comparable] struct {
+	Key   T
+	Count uint64
+}
+
+// The HeavyHitters struct tracks the k keys with the largest estimated counts in a stream.
+// Counts are estimated by a count-min sketch; the tracked keys are kept in a min-heap
+// whose top is the candidate evicted when a heavier key arrives.
+type HeavyHitters[T comparable] struct {
+	k       int
+	sketch  *Sketch[T]
+	tracked map[T]uint64
+	heap    *heap.Heap[Entry[T]]
+	mu      sync.Mutex
+}
+
+// NewHeavyHitters creates a new tracker of the k heaviest keys using a sketch with error
+// epsilon and confidence 1-delta.
+func NewHeavyHitters[T comparable](k int, epsilon, delta float64, hash hashing.Hasher[T]) *HeavyHitters[T] {
+	return NewHeavyHittersWithConfig(k, ConfigFor(epsilon, delta), hash)
+}
+
+// NewHeavyHittersWithConfig creates a new tracker of the k heaviest keys using a sketch with the given dimensions.
+func NewHeavyHittersWithConfig[T comparable](k int, config Config, hash hashing.Hasher[T]) *HeavyHitters[T] {
+	h := &HeavyHitters[T]{
+		k:      k,
+		sketch: NewWithConfig(config, hash),
+	}
+	h.reset()
+	return h
+}
+
+// reset empties the tracked keys.
+func (h *HeavyHitters[T]) reset() {
+	h.tracked = make(map[T]uint64, h.k)
+	h.heap = heap.NewWithLess(func(a, b Entry[T]) bool {
+		return a.Count < b.Count
+	})
+}
+
+// track considers the key with the given estimate for the top k.
+func (h *HeavyHitters[T]) track(key T, estimate uint64) {
+	if _, ok := h.tracked[key]; ok {
+		// The heap entry is now stale; it is refreshed when it reaches the top.
+		h.tracked[key] = estimate
+		return
+	}
+	if len(h.tracked) < h.k {
+		h.tracked[key] = estimate
+		h.heap.Push(Entry[T]{Key: key, Count: estimate})
+		return
+	}
+	if h.k == 0 {
+		return
+	}
+	top, _ := h.heap.Peek()
+	for top.Count != h.tracked[top.Key] {
+		h.heap.Pop()
+		h.heap.Push(Entry[T]{Key: top.Key, Count: h.tracked[top.Key]})
+		top, _ = h.heap.Peek()
+	}
+	if estimate > top.Count {
+		h.heap.Pop()
+		delete(h.tracked, top.Key)
+		h.tracked[key] = estimate
+		h.heap.Push(Entry[T]{Key: key, Count: estimate})
+	}
+}
+
+// Add increments the count of the key by n in a concurrency-safe manner and returns its new estimate.
+func (h *HeavyHitters[T]) Add(key T, n uint64) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	estimate := h.sketch.Add(key, n)
+	h.track(key, estimate)
+	return estimate
+}
+
+// Estimate returns an upper bound of the count of the key in a concurrency-safe manner.
+func (h *HeavyHitters[T]) Estimate(key T) uint64 {
+	return h.sketch.Estimate(key)
+}
+
+// Total returns the sum of all counts added in a concurrency-safe manner.
+func (h *HeavyHitters[T]) Total() uint64 {
+	return h.sketch.Total()
+}
+
+// TopK returns the tracked keys ordered by decreasing estimated count in a concurrency-safe manner.
+func (h *HeavyHitters[T]) TopK() []Entry[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entries := make([]Entry[T], 0, len(h.tracked))
+	for key, count := range h.tracked {
+		entries = append(entries, Entry[T]{Key: key, Count: count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Count > entries[j].Count
+	})
+	return entries
+}
+
+// Merge adds the counts of other to h in a concurrency-safe manner and recomputes the top k
+// from the keys tracked by either. Both trackers must use sketches of the same dimensions
+// and the same Hasher.
+func (h *HeavyHitters[T]) Merge(other *HeavyHitters[T]) error {
+	other.mu.Lock()
+	candidates := make([]T, 0, len(other.tracked))
+	for key := range other.tracked {
+		candidates = append(candidates, key)
+	}
+	other.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.sketch.Merge(other.sketch); err != nil {
+		return err
+	}
+	for key := range h.tracked {
+		candidates = append(candidates, key)
+	}
+	h.reset()
+	for _, key := range candidates {
+		h.track(key, h.sketch.Estimate(key))
+	}
+	return nil
+}
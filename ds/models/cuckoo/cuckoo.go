@@ -0,0 +1,313 @@
+// Description: This package contains the implementation of a cuckoo filter.
+package cuckoo
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"math/rand"
+	"sync"
+
+	"github.com/mmygods/gods/ds/hashing"
+)
+
+var (
+	// ErrFull is returned by Insert when no slot could be freed within the relocation limit.
+	// The filter is left unchanged.
+	ErrFull = errors.New("cuckoo: filter is full")
+	// ErrInvalidData is returned when unmarshaling data that does not hold a filter.
+	ErrInvalidData = errors.New("cuckoo: invalid data")
+)
+
+// magic identifies the binary format of a filter.
+var magic = [4]byte{'G', 'C', 'F', '1'}
+
+// headerSize is the size of the magic, configuration, bucket count and item count in the binary format.
+const headerSize = 4 + 1 + 1 + 4 + 8 + 8
+
+// Config holds the tuning parameters of a filter.
+type Config struct {
+	// FingerprintBits is the size of each stored fingerprint, between 1 and 32.
+	// The false-positive rate is roughly 2*BucketSize/2^FingerprintBits.
+	FingerprintBits int
+	// BucketSize is the number of fingerprints per bucket, between 1 and 255.
+	BucketSize int
+	// MaxKicks bounds the number of relocations attempted by a single Insert, between 1 and
+	// MaxKicksLimit.
+	MaxKicks int
+}
+
+// MaxKicksLimit is the largest MaxKicks of a filter.
+const MaxKicksLimit = 1 << 16
+
+// DefaultConfig is a configuration with a false-positive rate of about 0.012%.
+var DefaultConfig = Config{FingerprintBits: 16, BucketSize: 4, MaxKicks: 500}
+
+// The Filter struct represents a cuckoo filter over keys of type T.
+// An empty slot holds the fingerprint zero.
+type Filter[T any] struct {
+	table      []uint32
+	numBuckets uint64
+	config     Config
+	count      int
+	hash       hashing.Hasher[T]
+	rng        *rand.Rand
+	mu         sync.RWMutex
+}
+
+// New creates a new filter able to hold about capacity keys using DefaultConfig.
+func New[T any](capacity int, hash hashing.Hasher[T]) *Filter[T] {
+	return NewWithConfig(capacity, DefaultConfig, hash)
+}
+
+// NewWithConfig creates a new filter able to hold about capacity keys using config.
+// The number of buckets is rounded up to a power of two.
+func NewWithConfig[T any](capacity int, config Config, hash hashing.Hasher[T]) *Filter[T] {
+	if config.FingerprintBits < 1 || config.FingerprintBits > 32 {
+		panic("cuckoo: fingerprint size must be between 1 and 32 bits")
+	}
+	if config.BucketSize < 1 || config.BucketSize > 255 {
+		panic("cuckoo: bucket size must be between 1 and 255")
+	}
+	if config.MaxKicks < 1 || config.MaxKicks > MaxKicksLimit {
+		panic("cuckoo: maximum number of kicks must be between 1 and MaxKicksLimit")
+	}
+	if capacity < 1 {
+		capacity = 1
+	}
+	buckets := uint64(capacity+config.BucketSize-1) / uint64(config.BucketSize)
+	numBuckets := uint64(1) << bits.Len64(buckets-1)
+	return &Filter[T]{
+		table:      make([]uint32, numBuckets*uint64(config.BucketSize)),
+		numBuckets: numBuckets,
+		config:     config,
+		hash:       hash,
+		rng:        rand.New(rand.NewSource(1)),
+	}
+}
+
+// Unmarshal creates a new filter from data produced by MarshalBinary.
+func Unmarshal[T any](data []byte, hash hashing.Hasher[T]) (*Filter[T], error) {
+	f := &Filter[T]{hash: hash, rng: rand.New(rand.NewSource(1))}
+	if err := f.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// locate returns the fingerprint of the key and its two candidate buckets.
+func (f *Filter[T]) locate(key T) (uint32, uint64, uint64) {
+	h := f.hash(key)
+	fingerprint := uint32(h>>32) & (1<<f.config.FingerprintBits - 1)
+	if fingerprint == 0 {
+		fingerprint = 1
+	}
+	i1 := h & (f.numBuckets - 1)
+	return fingerprint, i1, f.altIndex(i1, fingerprint)
+}
+
+// altIndex returns the other candidate bucket of a fingerprint stored in bucket i.
+func (f *Filter[T]) altIndex(i uint64, fingerprint uint32) uint64 {
+	return (i ^ hashing.Mix64(uint64(fingerprint))) & (f.numBuckets - 1)
+}
+
+// bucket returns the slots of bucket i.
+func (f *Filter[T]) bucket(i uint64) []uint32 {
+	size := uint64(f.config.BucketSize)
+	return f.table[i*size : (i+1)*size]
+}
+
+// put stores the fingerprint in a free slot of bucket i.
+func (f *Filter[T]) put(i uint64, fingerprint uint32) bool {
+	bucket := f.bucket(i)
+	for s, slot := range bucket {
+		if slot == 0 {
+			bucket[s] = fingerprint
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the slot index in bucket i holding the fingerprint, or -1.
+func (f *Filter[T]) find(i uint64, fingerprint uint32) int {
+	for s, slot := range f.bucket(i) {
+		if slot == fingerprint {
+			return s
+		}
+	}
+	return -1
+}
+
+// insert stores the fingerprint of the key, relocating others if both buckets are full.
+// A failed relocation is undone so that no stored fingerprint is lost.
+func (f *Filter[T]) insert(key T) error {
+	fingerprint, i1, i2 := f.locate(key)
+	if f.put(i1, fingerprint) || f.put(i2, fingerprint) {
+		f.count++
+		return nil
+	}
+	i := i1
+	if f.rng.Intn(2) == 1 {
+		i = i2
+	}
+	path := make([]int, 0, f.config.MaxKicks)
+	current := fingerprint
+	for n := 0; n < f.config.MaxKicks; n++ {
+		slot := int(i)*f.config.BucketSize + f.rng.Intn(f.config.BucketSize)
+		current, f.table[slot] = f.table[slot], current
+		path = append(path, slot)
+		i = f.altIndex(i, current)
+		if f.put(i, current) {
+			f.count++
+			return nil
+		}
+	}
+	for n := len(path) - 1; n >= 0; n-- {
+		current, f.table[path[n]] = f.table[path[n]], current
+	}
+	return ErrFull
+}
+
+// lookup reports whether the fingerprint of the key is stored.
+func (f *Filter[T]) lookup(key T) bool {
+	fingerprint, i1, i2 := f.locate(key)
+	return f.find(i1, fingerprint) >= 0 || f.find(i2, fingerprint) >= 0
+}
+
+// delete removes one copy of the fingerprint of the key.
+func (f *Filter[T]) delete(key T) bool {
+	fingerprint, i1, i2 := f.locate(key)
+	for _, i := range [2]uint64{i1, i2} {
+		if s := f.find(i, fingerprint); s >= 0 {
+			f.bucket(i)[s] = 0
+			f.count--
+			return true
+		}
+	}
+	return false
+}
+
+// Insert adds the key to the filter in a concurrency-safe manner.
+// It returns ErrFull if the filter has no room for the key.
+func (f *Filter[T]) Insert(key T) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.insert(key)
+}
+
+// Lookup returns false if the key is definitely not in the filter, in a concurrency-safe manner.
+// A true result may be a false positive.
+func (f *Filter[T]) Lookup(key T) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.lookup(key)
+}
+
+// Delete removes the key from the filter in a concurrency-safe manner. Only keys that were
+// inserted may be deleted; deleting any other key may remove a colliding key instead.
+func (f *Filter[T]) Delete(key T) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.delete(key)
+}
+
+// Count returns the number of keys in the filter in a concurrency-safe manner.
+func (f *Filter[T]) Count() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.count
+}
+
+// Capacity returns the number of fingerprint slots in a concurrency-safe manner.
+func (f *Filter[T]) Capacity() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.table)
+}
+
+// LoadFactor returns the fraction of occupied slots in a concurrency-safe manner.
+func (f *Filter[T]) LoadFactor() float64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return float64(f.count) / float64(len(f.table))
+}
+
+// Clear removes every key from the filter in a concurrency-safe manner.
+func (f *Filter[T]) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	clear(f.table)
+	f.count = 0
+}
+
+// fingerprintBytes returns the number of bytes used to encode one fingerprint.
+func fingerprintBytes(fingerprintBits int) int {
+	return (fingerprintBits + 7) / 8
+}
+
+// MarshalBinary encodes the filter in a concurrency-safe manner. The hash function is not
+// encoded; the filter must be unmarshaled with the same Hasher.
+func (f *Filter[T]) MarshalBinary() ([]byte, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	width := fingerprintBytes(f.config.FingerprintBits)
+	data := make([]byte, headerSize, headerSize+width*len(f.table))
+	copy(data, magic[:])
+	data[4] = byte(f.config.FingerprintBits)
+	data[5] = byte(f.config.BucketSize)
+	binary.LittleEndian.PutUint32(data[6:], uint32(f.config.MaxKicks))
+	binary.LittleEndian.PutUint64(data[10:], f.numBuckets)
+	binary.LittleEndian.PutUint64(data[18:], uint64(f.count))
+	var buf [4]byte
+	for _, fingerprint := range f.table {
+		binary.LittleEndian.PutUint32(buf[:], fingerprint)
+		data = append(data, buf[:width]...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the filter with data produced by MarshalBinary,
+// in a concurrency-safe manner. The hash function of the filter is kept.
+func (f *Filter[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize || [4]byte(data[:4]) != magic {
+		return ErrInvalidData
+	}
+	config := Config{
+		FingerprintBits: int(data[4]),
+		BucketSize:      int(data[5]),
+		MaxKicks:        int(binary.LittleEndian.Uint32(data[6:])),
+	}
+	numBuckets := binary.LittleEndian.Uint64(data[10:])
+	count := binary.LittleEndian.Uint64(data[18:])
+	if config.FingerprintBits < 1 || config.FingerprintBits > 32 || config.BucketSize < 1 ||
+		config.MaxKicks < 1 || config.MaxKicks > MaxKicksLimit ||
+		numBuckets == 0 || numBuckets&(numBuckets-1) != 0 || numBuckets > uint64(len(data)) {
+		return ErrInvalidData
+	}
+	width := fingerprintBytes(config.FingerprintBits)
+	slots := numBuckets * uint64(config.BucketSize)
+	if uint64(len(data)-headerSize) != slots*uint64(width) || count > slots {
+		return ErrInvalidData
+	}
+	table := make([]uint32, slots)
+	used := uint64(0)
+	var buf [4]byte
+	for i := range table {
+		copy(buf[:], data[headerSize+i*width:headerSize+(i+1)*width])
+		table[i] = binary.LittleEndian.Uint32(buf[:])
+		if table[i]>>config.FingerprintBits != 0 {
+			return ErrInvalidData
+		}
+		if table[i] != 0 {
+			used++
+		}
+	}
+	if used != count {
+		return ErrInvalidData
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.table, f.numBuckets, f.config, f.count = table, numBuckets, config, int(count)
+	return nil
+}
@@ -0,0 +1,166 @@
+package cuckoo_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/mmygods/gods/ds/hashing"
+	"github.com/mmygods/gods/ds/models/cuckoo"
+)
+
+func TestCuckooAgainstReference(t *testing.T) {
+	f := cuckoo.New(5000, hashing.Int)
+	reference := map[int]int{}
+	r := rand.New(rand.NewSource(1))
+	for step := 0; step < 20000; step++ {
+		key := r.Intn(3000)
+		if r.Intn(3) == 0 && reference[key] > 0 {
+			if !f.Delete(key) {
+				t.Fatalf("Delete(%d) failed for an inserted key", key)
+			}
+			reference[key]--
+		} else if reference[key] < 2 {
+			if err := f.Insert(key); err != nil {
+				t.Fatalf("Insert(%d) failed: %v", key, err)
+			}
+			reference[key]++
+		}
+		if step%100 == 0 {
+			for key, copies := range reference {
+				if copies > 0 && !f.Lookup(key) {
+					t.Fatalf("False negative for %d", key)
+				}
+			}
+		}
+	}
+	total := 0
+	for _, copies := range reference {
+		total += copies
+	}
+	if f.Count() != total {
+		t.Errorf("Expected count %d, got %d", total, f.Count())
+	}
+}
+
+func TestCuckooFalsePositiveRate(t *testing.T) {
+	f := cuckoo.NewWithConfig(10000, cuckoo.Config{FingerprintBits: 12, BucketSize: 4, MaxKicks: 500}, hashing.Int)
+	for i := 0; i < 9000; i++ {
+		if err := f.Insert(i); err != nil {
+			t.Fatalf("Insert(%d) failed: %v", i, err)
+		}
+	}
+	positives := 0
+	for i := 9000; i < 109000; i++ {
+		if f.Lookup(i) {
+			positives++
+		}
+	}
+	if rate := float64(positives) / 100000; rate > 2*8.0/4096 {
+		t.Errorf("False-positive rate %g exceeds the expected bound", rate)
+	}
+}
+
+func TestCuckooFull(t *testing.T) {
+	f := cuckoo.NewWithConfig(8, cuckoo.Config{FingerprintBits: 16, BucketSize: 2, MaxKicks: 50}, hashing.Int)
+	inserted := 0
+	var err error
+	for ; inserted < 100; inserted++ {
+		if err = f.Insert(inserted); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, cuckoo.ErrFull) {
+		t.Fatalf("Expected ErrFull, got %v", err)
+	}
+	if f.Count() != inserted || f.LoadFactor() > 1 {
+		t.Errorf("Expected count %d, got %d", inserted, f.Count())
+	}
+	for i := 0; i < inserted; i++ {
+		if !f.Lookup(i) {
+			t.Errorf("Failed insert lost key %d", i)
+		}
+	}
+}
+
+func TestCuckooMarshal(t *testing.T) {
+	for _, bits := range []int{7, 16, 32} {
+		config := cuckoo.Config{FingerprintBits: bits, BucketSize: 4, MaxKicks: 100}
+		f := cuckoo.NewWithConfig(1000, config, hashing.String)
+		keys := []string{"alice", "bob", "carol", "dave"}
+		for _, key := range keys {
+			f.Insert(key)
+		}
+		data, _ := f.MarshalBinary()
+		loaded, err := cuckoo.Unmarshal(data, hashing.String)
+		if err != nil {
+			t.Fatalf("%d bits: unexpected error %v", bits, err)
+		}
+		if loaded.Count() != len(keys) || loaded.Capacity() != f.Capacity() {
+			t.Errorf("%d bits: expected counts to survive a round trip", bits)
+		}
+		for _, key := range keys {
+			if !loaded.Lookup(key) {
+				t.Errorf("%d bits: loaded filter lost %q", bits, key)
+			}
+		}
+		if !loaded.Delete("bob") || loaded.Count() != len(keys)-1 {
+			t.Errorf("%d bits: expected loaded filter to support Delete", bits)
+		}
+		if _, err := cuckoo.Unmarshal(data[:len(data)-1], hashing.String); !errors.Is(err, cuckoo.ErrInvalidData) {
+			t.Errorf("%d bits: expected ErrInvalidData, got %v", bits, err)
+		}
+	}
+}
+
+func TestCuckooMaxKicks(t *testing.T) {
+	for _, kicks := range []int{-1, 0, cuckoo.MaxKicksLimit + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic for MaxKicks %d", kicks)
+				}
+			}()
+			cuckoo.NewWithConfig(8, cuckoo.Config{FingerprintBits: 16, BucketSize: 2, MaxKicks: kicks}, hashing.Int)
+		}()
+	}
+}
+
+func TestCuckooUnmarshalInvalid(t *testing.T) {
+	f := cuckoo.NewWithConfig(16, cuckoo.Config{FingerprintBits: 7, BucketSize: 4, MaxKicks: 100}, hashing.Int)
+	for i := 0; i < 4; i++ {
+		f.Insert(i)
+	}
+	data, _ := f.MarshalBinary()
+	const headerSize = 26
+	tests := []struct {
+		name   string
+		modify func(data []byte)
+	}{
+		{"Zero MaxKicks", func(data []byte) { binary.LittleEndian.PutUint32(data[6:], 0) }},
+		{"Oversized MaxKicks", func(data []byte) { binary.LittleEndian.PutUint32(data[6:], 1<<31) }},
+		{"Wide fingerprint", func(data []byte) {
+			for i := headerSize; i < len(data); i++ {
+				if data[i] != 0 {
+					data[i] |= 0x80
+					return
+				}
+			}
+		}},
+		{"Count above the stored fingerprints", func(data []byte) { binary.LittleEndian.PutUint64(data[18:], 5) }},
+		{"Count below the stored fingerprints", func(data []byte) { binary.LittleEndian.PutUint64(data[18:], 3) }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modified := append([]byte(nil), data...)
+			test.modify(modified)
+			if _, err := cuckoo.Unmarshal(modified, hashing.Int); !errors.Is(err, cuckoo.ErrInvalidData) {
+				t.Errorf("Expected ErrInvalidData, got %v", err)
+			}
+		})
+	}
+	if _, err := cuckoo.Unmarshal(data, hashing.Int); err != nil {
+		t.Errorf("Expected the unmodified data to load, got %v", err)
+	}
+}
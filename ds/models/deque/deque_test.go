@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/mmygods/gods/ds/collections"
+	"github.com/mmygods/gods/ds/collections/collectionstest"
 	"github.com/mmygods/gods/ds/models/dll"
 )
 
@@ -59,3 +60,15 @@ func TestDequeInterface(t *testing.T) {
 		t.Error("Expected deque to be empty")
 	}
 }
+
+func TestDequeConformance(t *testing.T) {
+	collectionstest.TestDeque(t, func() collections.Deque[int] {
+		return &dll.DoublyLinkedList[int]{}
+	})
+}
+
+func TestBoundedDequeConformance(t *testing.T) {
+	collectionstest.TestDeque(t, func() collections.Deque[int] {
+		return dll.NewBounded[int](1<<20, dll.Block)
+	})
+}
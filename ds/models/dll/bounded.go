@@ -0,0 +1,99 @@
+package dll
+
+import "sync"
+
+// OverflowPolicy decides what a bounded list does when an element is added while it is full.
+type OverflowPolicy int
+
+const (
+	// Reject leaves the list unchanged and makes the adding method return false.
+	Reject OverflowPolicy = iota
+	// DropOldest removes the element at the opposite end to make room: adding at the end
+	// removes the first element and adding at the beginning removes the last one.
+	DropOldest
+	// DropNewest discards the element being added, leaves the list unchanged and makes the
+	// adding method return true.
+	DropNewest
+	// Block makes the adding method wait until an element is removed.
+	Block
+)
+
+// String returns the name of the policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case Reject:
+		return "Reject"
+	case DropOldest:
+		return "DropOldest"
+	case DropNewest:
+		return "DropNewest"
+	case Block:
+		return "Block"
+	default:
+		return "OverflowPolicy(?)"
+	}
+}
+
+// NewBounded creates a new list holding at most capacity elements, which applies policy when
+// an element is added while it is full. It panics if capacity is less than 1.
+func NewBounded[T any](capacity int, policy OverflowPolicy) *DoublyLinkedList[T] {
+	if capacity < 1 {
+		panic("dll: capacity must be at least 1")
+	}
+	dll := &DoublyLinkedList[T]{capacity: capacity, policy: policy}
+	dll.notFull = sync.NewCond(&dll.mu)
+	return dll
+}
+
+// admit applies the overflow policy before an element is added at the beginning of the list
+// if front is set or at its end otherwise. It reports whether the element may be added and,
+// if not, the result the adding method returns. It must be called with the write lock held.
+func (dll *DoublyLinkedList[T]) admit(front bool) (add, result bool) {
+	if dll.capacity == 0 || dll.length < dll.capacity {
+		return true, true
+	}
+	switch dll.policy {
+	case DropOldest:
+		if front {
+			dll.popNode()
+		} else {
+			dll.popFirstNode()
+		}
+		return true, true
+	case DropNewest:
+		return false, true
+	case Block:
+		for dll.length >= dll.capacity {
+			dll.notFull.Wait()
+		}
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// released wakes the methods blocked on a full list after an element was removed.
+func (dll *DoublyLinkedList[T]) released() {
+	if dll.notFull != nil {
+		dll.notFull.Broadcast()
+	}
+}
+
+// Capacity returns the maximum number of elements of a bounded list, or 0 if the list is
+// unbounded.
+func (dll *DoublyLinkedList[T]) Capacity() int {
+	return dll.capacity
+}
+
+// Policy returns the overflow policy of a bounded list.
+func (dll *DoublyLinkedList[T]) Policy() OverflowPolicy {
+	return dll.policy
+}
+
+// IsFull returns true if a bounded list holds as many elements as its capacity in a
+// concurrency-safe manner.
+func (dll *DoublyLinkedList[T]) IsFull() bool {
+	dll.rLock()
+	defer dll.rUnlock()
+	return dll.capacity > 0 && dll.length >= dll.capacity
+}
@@ -0,0 +1,181 @@
+package dll_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mmygods/gods/ds/collections"
+	"github.com/mmygods/gods/ds/models/dll"
+)
+
+func elements(list collections.List[int]) []int {
+	var values []int
+	for v := range list.Range() {
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestBoundedListPolicies(t *testing.T) {
+	tests := []struct {
+		policy   dll.OverflowPolicy
+		actions  func(list collections.List[int]) []bool
+		results  []bool
+		expected []int
+	}{
+		{
+			policy: dll.Reject,
+			actions: func(list collections.List[int]) []bool {
+				return []bool{list.Append(4), list.Prepend(0), list.Insert(1, 5)}
+			},
+			results:  []bool{false, false, false},
+			expected: []int{1, 2, 3},
+		},
+		{
+			policy: dll.DropOldest,
+			actions: func(list collections.List[int]) []bool {
+				return []bool{list.Append(4), list.Prepend(0), list.Insert(2, 5), list.Insert(0, 6)}
+			},
+			results:  []bool{true, true, true, true},
+			expected: []int{6, 2, 5},
+		},
+		{
+			policy: dll.DropNewest,
+			actions: func(list collections.List[int]) []bool {
+				return []bool{list.Append(4), list.Prepend(0), list.Insert(1, 5)}
+			},
+			results:  []bool{true, true, true},
+			expected: []int{1, 2, 3},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.policy.String(), func(t *testing.T) {
+			list := dll.NewBounded[int](3, test.policy)
+			for i := 1; i <= 3; i++ {
+				if !list.Append(i) {
+					t.Fatalf("Expected Append(%d) to succeed below capacity", i)
+				}
+			}
+			if !list.IsFull() || list.Capacity() != 3 || list.Policy() != test.policy {
+				t.Fatal("Expected a full list of capacity 3")
+			}
+			if results := test.actions(list); !slices.Equal(results, test.results) {
+				t.Errorf("Expected results %v, got %v", test.results, results)
+			}
+			if values := elements(list); !slices.Equal(values, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, values)
+			}
+			if list.Insert(5, 9) {
+				t.Error("Expected Insert out of range to fail")
+			}
+		})
+	}
+}
+
+func TestBoundedListTail(t *testing.T) {
+	var deque collections.Deque[int] = dll.NewBounded[int](100, dll.DropOldest)
+	for i := 0; i < 1000; i++ {
+		deque.Append(i)
+	}
+	if deque.Length() != 100 {
+		t.Fatalf("Expected length 100, got %d", deque.Length())
+	}
+	if first, _ := deque.PopFirst(); first != 900 {
+		t.Errorf("Expected the oldest kept element to be 900, got %d", first)
+	}
+}
+
+func TestBoundedListBlock(t *testing.T) {
+	list := dll.NewBounded[int](2, dll.Block)
+	list.Append(1)
+	list.Append(2)
+	done := make(chan bool)
+	go func() {
+		done <- list.Append(3)
+	}()
+	select {
+	case <-done:
+		t.Fatal("Expected Append to block on a full list")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if v, ok := list.PopFirst(); !ok || v != 1 {
+		t.Fatalf("Expected 1, got %d", v)
+	}
+	if !<-done {
+		t.Fatal("Expected the blocked Append to succeed")
+	}
+	if values := elements(list); !slices.Equal(values, []int{2, 3}) {
+		t.Errorf("Expected [2 3], got %v", values)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				list.Prepend(j)
+			}
+		}()
+	}
+	popped := 0
+	for popped < 200 {
+		if _, ok := list.Pop(); ok {
+			popped++
+		}
+		if list.Length() > 2 {
+			t.Fatalf("Expected at most 2 elements, got %d", list.Length())
+		}
+	}
+	wg.Wait()
+	if list.Length() != 2 {
+		t.Errorf("Expected 2 elements left, got %d", list.Length())
+	}
+}
+
+func TestBoundedListBlockInsert(t *testing.T) {
+	tests := []struct {
+		name     string
+		index    int
+		shrink   func(list *dll.DoublyLinkedList[int])
+		inserted bool
+		expected []int
+	}{
+		{"Index still in range", 1, func(list *dll.DoublyLinkedList[int]) { list.PopFirst() }, true, []int{2, 3}},
+		{"Tail before the elements removed", 2, func(list *dll.DoublyLinkedList[int]) { list.Clear() }, false, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list := dll.NewBounded[int](2, dll.Block)
+			list.Append(1)
+			list.Append(2)
+			done := make(chan bool)
+			go func() {
+				done <- list.Insert(test.index, 3)
+			}()
+			select {
+			case <-done:
+				t.Fatal("Expected Insert to block on a full list")
+			case <-time.After(20 * time.Millisecond):
+			}
+			test.shrink(list)
+			if inserted := <-done; inserted != test.inserted {
+				t.Errorf("Expected the blocked Insert to return %v", test.inserted)
+			}
+			if values := elements(list); !slices.Equal(values, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, values)
+			}
+		})
+	}
+}
+
+func TestNewBoundedPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a zero capacity")
+		}
+	}()
+	dll.NewBounded[int](0, dll.Reject)
+}
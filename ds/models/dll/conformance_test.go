@@ -0,0 +1,27 @@
+package dll_test
+
+import (
+	"testing"
+
+	"github.com/mmygods/gods/ds/collections"
+	"github.com/mmygods/gods/ds/collections/collectionstest"
+	"github.com/mmygods/gods/ds/models/dll"
+)
+
+func TestListConformance(t *testing.T) {
+	collectionstest.TestList(t, func() collections.List[int] {
+		return &dll.DoublyLinkedList[int]{}
+	})
+}
+
+func TestLruListConformance(t *testing.T) {
+	collectionstest.TestLruList(t, func() collections.LruList[int, *dll.DllNode[int]] {
+		return &dll.DoublyLinkedList[int]{}
+	})
+}
+
+func TestBoundedListConformance(t *testing.T) {
+	collectionstest.TestList(t, func() collections.List[int] {
+		return dll.NewBounded[int](1<<20, dll.Reject)
+	})
+}
@@ -12,11 +12,18 @@ type DllNode[T any] struct {
 }
 
 // The DoublyLinkedList struct represents a doubly linked list.
+// The zero value is an empty list without a capacity; use NewBounded for a bounded list.
 type DoublyLinkedList[T any] struct {
-	length int
-	head   *DllNode[T]
-	tail   *DllNode[T]
-	mu     sync.RWMutex
+	length   int
+	head     *DllNode[T]
+	tail     *DllNode[T]
+	capacity int
+	policy   OverflowPolicy
+	notFull  *sync.Cond
+	// observers are notified of every change while the write lock is held.
+	observers    []observer[T]
+	nextObserver int
+	mu           sync.RWMutex
 }
 
 func zeroValue[T any]() T {
@@ -51,6 +58,7 @@ func (dll *DoublyLinkedList[T]) appendNode(node *DllNode[T]) bool {
 		dll.tail = node
 	}
 	dll.length++
+	dll.notify(Inserted, dll.length-1, node.data, zeroValue[T]())
 	return true
 }
 
@@ -71,6 +79,7 @@ func (dll *DoublyLinkedList[T]) prependNode(node *DllNode[T]) bool {
 		dll.head = node
 	}
 	dll.length++
+	dll.notify(Inserted, 0, node.data, zeroValue[T]())
 	return true
 }
 
@@ -97,6 +106,8 @@ func (dll *DoublyLinkedList[T]) popNode() (*DllNode[T], bool) {
 		dll.tail.next = nil
 	}
 	dll.length--
+	dll.released()
+	dll.notify(Removed, dll.length, node.data, zeroValue[T]())
 	node.next = nil
 	node.prev = nil
 	return node, true
@@ -126,6 +137,8 @@ func (dll *DoublyLinkedList[T]) popFirstNode() (*DllNode[T], bool) {
 		dll.head.prev = nil
 	}
 	dll.length--
+	dll.released()
+	dll.notify(Removed, 0, node.data, zeroValue[T]())
 	node.next = nil
 	node.prev = nil
 	return node, true
@@ -158,7 +171,9 @@ func (dll *DoublyLinkedList[T]) set(index int, data T) bool {
 	if node == nil {
 		return false
 	}
+	previous := node.data
 	node.data = data
+	dll.notify(Updated, index, data, previous)
 	return true
 }
 
@@ -181,6 +196,7 @@ func (dll *DoublyLinkedList[T]) insert(index int, data T) bool {
 	node.next = nextNode
 	nextNode.prev = node
 	dll.length++
+	dll.notify(Inserted, index, data, zeroValue[T]())
 	return true
 }
 
@@ -208,6 +224,10 @@ func (dll *DoublyLinkedList[T]) deleteNode(node *DllNode[T]) bool {
 		_, ok := dll.pop()
 		return ok
 	}
+	index := -1
+	if len(dll.observers) > 0 {
+		index = dll.indexOf(node)
+	}
 	prevNode := node.prev
 	nextNode := node.next
 	prevNode.next = nextNode
@@ -215,9 +235,35 @@ func (dll *DoublyLinkedList[T]) deleteNode(node *DllNode[T]) bool {
 	node.next = nil
 	node.prev = nil
 	dll.length--
+	dll.released()
+	dll.notify(Removed, index, node.data, zeroValue[T]())
 	return true
 }
 
+// indexOf returns the index of a node in the list.
+func (dll *DoublyLinkedList[T]) indexOf(node *DllNode[T]) int {
+	index := 0
+	for n := dll.head; n != nil && n != node; n = n.next {
+		index++
+	}
+	return index
+}
+
+// clear removes every element from the list.
+func (dll *DoublyLinkedList[T]) clear() {
+	for node := dll.head; node != nil; {
+		next := node.next
+		node.next = nil
+		node.prev = nil
+		node = next
+	}
+	dll.head = nil
+	dll.tail = nil
+	dll.length = 0
+	dll.released()
+	dll.notify(Cleared, 0, zeroValue[T](), zeroValue[T]())
+}
+
 func (dll *DoublyLinkedList[T]) isEmpty() bool {
 	return dll.length == 0
 }
@@ -247,16 +293,24 @@ func (dll *DoublyLinkedList[T]) rUnlock() {
 }
 
 // Append adds an element to the end of the list in a concurrency-safe manner.
+// A full bounded list applies its overflow policy.
 func (dll *DoublyLinkedList[T]) Append(data T) bool {
 	dll.lock()
 	defer dll.unlock()
+	if add, result := dll.admit(false); !add {
+		return result
+	}
 	return dll.append(data)
 }
 
 // Prepend adds a node to the beginning of the list in a concurrency-safe manner.
+// A full bounded list applies its overflow policy.
 func (dll *DoublyLinkedList[T]) Prepend(data T) bool {
 	dll.lock()
 	defer dll.unlock()
+	if add, result := dll.admit(true); !add {
+		return result
+	}
 	return dll.prepend(data)
 }
 
@@ -289,9 +343,28 @@ func (dll *DoublyLinkedList[T]) Set(index int, data T) bool {
 }
 
 // Insert adds an element at the specified index in a concurrency-safe manner.
+// A full bounded list applies its overflow policy as Prepend does at index 0 and as
+// Append does elsewhere. With the Block policy, the index is checked again once there is
+// room, so Insert returns false without adding the element if the list shrank below the
+// index while it waited.
 func (dll *DoublyLinkedList[T]) Insert(index int, data T) bool {
 	dll.lock()
 	defer dll.unlock()
+	if index < 0 || index > dll.length {
+		return false
+	}
+	length := dll.length
+	if add, result := dll.admit(index == 0); !add {
+		return result
+	}
+	if index > 0 && dll.policy == DropOldest && dll.length < length {
+		// The first element was dropped, so the element to insert after moved back.
+		index--
+	}
+	if index > dll.length {
+		// Other goroutines removed elements while a Block list was full.
+		return false
+	}
 	return dll.insert(index, data)
 }
 
@@ -302,6 +375,13 @@ func (dll *DoublyLinkedList[T]) Delete(index int) bool {
 	return dll.delete(index)
 }
 
+// Clear removes every element from the list in a concurrency-safe manner.
+func (dll *DoublyLinkedList[T]) Clear() {
+	dll.lock()
+	defer dll.unlock()
+	dll.clear()
+}
+
 // IsEmpty checks if the list is empty in a concurrency-safe manner.
 func (dll *DoublyLinkedList[T]) IsEmpty() bool {
 	dll.rLock()
@@ -347,11 +427,31 @@ func (dll *DoublyLinkedList[T]) DeleteNode(node *DllNode[T]) bool {
 func (dll *DoublyLinkedList[T]) AppendNode(node *DllNode[T]) bool {
 	dll.lock()
 	defer dll.unlock()
+	if add, result := dll.admit(false); !add {
+		return result
+	}
 	return dll.appendNode(node)
 }
 
 func (dll *DoublyLinkedList[T]) PrependNode(node *DllNode[T]) bool {
 	dll.lock()
 	defer dll.unlock()
+	if add, result := dll.admit(true); !add {
+		return result
+	}
 	return dll.prependNode(node)
 }
+
+// PopNode removes and returns the last node in the list in a concurrency-safe manner.
+func (dll *DoublyLinkedList[T]) PopNode() (*DllNode[T], bool) {
+	dll.lock()
+	defer dll.unlock()
+	return dll.popNode()
+}
+
+// PopFirstNode removes and returns the first node in the list in a concurrency-safe manner.
+func (dll *DoublyLinkedList[T]) PopFirstNode() (*DllNode[T], bool) {
+	dll.lock()
+	defer dll.unlock()
+	return dll.popFirstNode()
+}
@@ -0,0 +1,197 @@
+package dll
+
+import "sync"
+
+// EventKind identifies the change described by an Event.
+type EventKind int
+
+const (
+	// Inserted reports that Value was added at Index.
+	Inserted EventKind = iota
+	// Removed reports that Value was removed from Index.
+	Removed
+	// Updated reports that the element at Index was replaced by Value, Previous being the
+	// element it replaced.
+	Updated
+	// Cleared reports that every element was removed.
+	Cleared
+)
+
+// String returns the name of the event kind.
+func (k EventKind) String() string {
+	switch k {
+	case Inserted:
+		return "Inserted"
+	case Removed:
+		return "Removed"
+	case Updated:
+		return "Updated"
+	case Cleared:
+		return "Cleared"
+	default:
+		return "EventKind(?)"
+	}
+}
+
+// Event describes a change of a list. Index is the position of the change at the time it was
+// made, counted from the beginning of the list.
+type Event[T any] struct {
+	Kind     EventKind
+	Index    int
+	Value    T
+	Previous T
+}
+
+// observer is a registered event callback.
+type observer[T any] struct {
+	id int
+	fn func(Event[T])
+}
+
+// notify delivers an event to every observer.
+func (dll *DoublyLinkedList[T]) notify(kind EventKind, index int, value, previous T) {
+	if len(dll.observers) == 0 {
+		return
+	}
+	event := Event[T]{Kind: kind, Index: index, Value: value, Previous: previous}
+	for _, o := range dll.observers {
+		o.fn(event)
+	}
+}
+
+// unobserve removes the observer with the id.
+func (dll *DoublyLinkedList[T]) unobserve(id int) {
+	dll.lock()
+	defer dll.unlock()
+	for i, o := range dll.observers {
+		if o.id == id {
+			dll.observers = append(dll.observers[:i:i], dll.observers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Observe registers fn to be called with every change of the list in a concurrency-safe
+// manner, and returns a function that unregisters it. fn is called synchronously while the
+// list is locked, in the order of the changes, so it must not call methods of the list.
+func (dll *DoublyLinkedList[T]) Observe(fn func(Event[T])) (cancel func()) {
+	dll.lock()
+	defer dll.unlock()
+	dll.nextObserver++
+	id := dll.nextObserver
+	dll.observers = append(dll.observers, observer[T]{id: id, fn: fn})
+	var once sync.Once
+	return func() {
+		once.Do(func() { dll.unobserve(id) })
+	}
+}
+
+// Subscribe registers a subscription receiving every change of the list asynchronously in
+// a concurrency-safe manner. Events are queued without blocking the list and delivered in
+// order on the Events channel. At most buffer events, or one if buffer is less than one, wait
+// in the queue besides the one being delivered; when the queue is full the oldest event is
+// dropped and counted by Dropped.
+func (dll *DoublyLinkedList[T]) Subscribe(buffer int) *Subscription[T] {
+	s := &Subscription[T]{
+		events: make(chan Event[T]),
+		buffer: max(buffer, 1),
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	s.cancel = dll.Observe(s.push)
+	go s.run()
+	return s
+}
+
+// Subscription delivers the changes of a list asynchronously. Create it with Subscribe.
+type Subscription[T any] struct {
+	events  chan Event[T]
+	queue   []Event[T]
+	buffer  int
+	dropped int
+	wake    chan struct{}
+	done    chan struct{}
+	cancel  func()
+	once    sync.Once
+	mu      sync.Mutex
+}
+
+// push queues an event, dropping the oldest one if the queue is full, and wakes the
+// delivering goroutine.
+func (s *Subscription[T]) push(event Event[T]) {
+	s.mu.Lock()
+	if len(s.queue) == s.buffer {
+		s.queue[0] = Event[T]{}
+		s.queue = s.queue[1:]
+		s.dropped++
+	}
+	s.queue = append(s.queue, event)
+	s.mu.Unlock()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// stopped returns true if the subscription was cancelled.
+func (s *Subscription[T]) stopped() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// run delivers queued events until the subscription is cancelled.
+func (s *Subscription[T]) run() {
+	defer close(s.events)
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			select {
+			case <-s.wake:
+				continue
+			case <-s.done:
+				return
+			}
+		}
+		event := s.queue[0]
+		s.queue[0] = Event[T]{}
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+		// select picks randomly among ready cases, so check for cancellation first to
+		// deliver nothing once Unsubscribe has returned.
+		if s.stopped() {
+			return
+		}
+		select {
+		case s.events <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Events returns the channel the changes are delivered on. It is closed by Unsubscribe.
+func (s *Subscription[T]) Events() <-chan Event[T] {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the queue was full.
+func (s *Subscription[T]) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Unsubscribe stops the subscription, discards undelivered events and closes the channel.
+// No event is received from Events once Unsubscribe has returned. It must not be called
+// from an observer registered with Observe.
+func (s *Subscription[T]) Unsubscribe() {
+	s.once.Do(func() {
+		s.cancel()
+		close(s.done)
+	})
+}
@@ -0,0 +1,118 @@
+package dll_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/dll"
+)
+
+func TestObserve(t *testing.T) {
+	list := &dll.DoublyLinkedList[string]{}
+	var events []dll.Event[string]
+	cancel := list.Observe(func(e dll.Event[string]) {
+		events = append(events, e)
+	})
+	list.Append("b")
+	list.Prepend("a")
+	list.Append("d")
+	list.Insert(2, "c")
+	list.Set(1, "B")
+	list.Delete(2)
+	list.DeleteNode(list.GetNode(1))
+	list.Pop()
+	list.PopFirst()
+	list.Append("x")
+	list.Clear()
+	cancel()
+	cancel()
+	list.Append("ignored")
+
+	expected := []dll.Event[string]{
+		{Kind: dll.Inserted, Index: 0, Value: "b"},
+		{Kind: dll.Inserted, Index: 0, Value: "a"},
+		{Kind: dll.Inserted, Index: 2, Value: "d"},
+		{Kind: dll.Inserted, Index: 2, Value: "c"},
+		{Kind: dll.Updated, Index: 1, Value: "B", Previous: "b"},
+		{Kind: dll.Removed, Index: 2, Value: "c"},
+		{Kind: dll.Removed, Index: 1, Value: "B"},
+		{Kind: dll.Removed, Index: 1, Value: "d"},
+		{Kind: dll.Removed, Index: 0, Value: "a"},
+		{Kind: dll.Inserted, Index: 0, Value: "x"},
+		{Kind: dll.Cleared},
+	}
+	if !slices.Equal(events, expected) {
+		t.Errorf("Expected events\n%v\ngot\n%v", expected, events)
+	}
+	if list.Length() != 1 {
+		t.Errorf("Expected the element added after cancel, got %d elements", list.Length())
+	}
+}
+
+func TestObserveEviction(t *testing.T) {
+	list := dll.NewBounded[int](2, dll.DropOldest)
+	var kinds []dll.EventKind
+	list.Observe(func(e dll.Event[int]) {
+		kinds = append(kinds, e.Kind)
+	})
+	list.Append(1)
+	list.Append(2)
+	list.Append(3)
+	expected := []dll.EventKind{dll.Inserted, dll.Inserted, dll.Removed, dll.Inserted}
+	if !slices.Equal(kinds, expected) {
+		t.Errorf("Expected %v, got %v", expected, kinds)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	list := &dll.DoublyLinkedList[int]{}
+	sub := list.Subscribe(100)
+	for i := 0; i < 100; i++ {
+		list.Append(i)
+	}
+	for i := 0; i < 100; i++ {
+		e := <-sub.Events()
+		if e.Kind != dll.Inserted || e.Index != i || e.Value != i {
+			t.Fatalf("Expected insertion of %d, got %v", i, e)
+		}
+	}
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	list.Append(100)
+	for e := range sub.Events() {
+		t.Errorf("Expected no event after Unsubscribe, got %v", e)
+	}
+}
+
+func TestSubscribeOverflow(t *testing.T) {
+	list := &dll.DoublyLinkedList[int]{}
+	sub := list.Subscribe(4)
+	defer sub.Unsubscribe()
+	for i := 0; i < 10; i++ {
+		list.Append(i)
+	}
+	var values []int
+	for e := range sub.Events() {
+		values = append(values, e.Value)
+		if e.Value == 9 {
+			break
+		}
+	}
+	// One event may already be on its way when the queue overflows.
+	if len(values) > 5 || len(values)+sub.Dropped() != 10 || !slices.IsSorted(values) {
+		t.Errorf("Expected at most 5 events in order and the rest dropped, got %v with %d dropped", values, sub.Dropped())
+	}
+}
+
+func TestUnsubscribeDiscards(t *testing.T) {
+	list := &dll.DoublyLinkedList[int]{}
+	for i := 0; i < 100; i++ {
+		sub := list.Subscribe(10)
+		list.Append(i)
+		list.Append(i)
+		sub.Unsubscribe()
+		for e := range sub.Events() {
+			t.Fatalf("Expected no event after Unsubscribe, got %v", e)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Description: This package contains the implementation of a Fenwick tree (binary indexed tree).
+package fenwick
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Number is the set of numeric types a Fenwick tree can aggregate.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// The Fenwick struct represents a Fenwick tree over a fixed number of values.
+// Ranges are half-open: [from, to) covers the indices from through to-1.
+type Fenwick[T Number] struct {
+	tree []T
+	mu   sync.RWMutex
+}
+
+// New creates a new Fenwick tree of n zero values.
+func New[T Number](n int) *Fenwick[T] {
+	return &Fenwick[T]{tree: make([]T, n+1)}
+}
+
+// FromSlice creates a new Fenwick tree over a copy of values in O(n).
+func FromSlice[T Number](values []T) *Fenwick[T] {
+	f := New[T](len(values))
+	copy(f.tree[1:], values)
+	for i := 1; i < len(f.tree); i++ {
+		if parent := i + i&-i; parent < len(f.tree) {
+			f.tree[parent] += f.tree[i]
+		}
+	}
+	return f
+}
+
+// add adds delta to the value at the zero-based index.
+func (f *Fenwick[T]) add(index int, delta T) {
+	for i := index + 1; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// prefixSum returns the sum of the first n values.
+func (f *Fenwick[T]) prefixSum(n int) T {
+	var sum T
+	for i := n; i > 0; i -= i & -i {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// lowerBound returns the smallest index whose prefix sum, inclusive, is at least target.
+func (f *Fenwick[T]) lowerBound(target T) (int, bool) {
+	n := len(f.tree) - 1
+	if n == 0 {
+		return -1, false
+	}
+	pos := 0
+	for step := 1 << (bits.Len(uint(n)) - 1); step > 0; step >>= 1 {
+		if next := pos + step; next <= n && f.tree[next] < target {
+			pos = next
+			target -= f.tree[next]
+		}
+	}
+	if pos >= n {
+		return -1, false
+	}
+	return pos, true
+}
+
+// Length returns the number of values in the tree.
+func (f *Fenwick[T]) Length() int {
+	return len(f.tree) - 1
+}
+
+// Add adds delta to the value at the index in a concurrency-safe manner.
+func (f *Fenwick[T]) Add(index int, delta T) bool {
+	if index < 0 || index >= f.Length() {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.add(index, delta)
+	return true
+}
+
+// Get returns the value at the index in a concurrency-safe manner.
+func (f *Fenwick[T]) Get(index int) (T, bool) {
+	return f.RangeSum(index, index+1)
+}
+
+// Set replaces the value at the index in a concurrency-safe manner.
+func (f *Fenwick[T]) Set(index int, value T) bool {
+	if index < 0 || index >= f.Length() {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.add(index, value-(f.prefixSum(index+1)-f.prefixSum(index)))
+	return true
+}
+
+// PrefixSum returns the sum of the first n values in a concurrency-safe manner.
+func (f *Fenwick[T]) PrefixSum(n int) (T, bool) {
+	if n < 0 || n > f.Length() {
+		return 0, false
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.prefixSum(n), true
+}
+
+// RangeSum returns the sum of the values in [from, to) in a concurrency-safe manner.
+func (f *Fenwick[T]) RangeSum(from, to int) (T, bool) {
+	if from < 0 || to > f.Length() || from > to {
+		return 0, false
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.prefixSum(to) - f.prefixSum(from), true
+}
+
+// LowerBound returns the smallest index i such that the sum of the values in [0, i]
+// is at least target, in a concurrency-safe manner. All values must be non-negative.
+func (f *Fenwick[T]) LowerBound(target T) (int, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.lowerBound(target)
+}
+
+// The Fenwick2D struct represents a two-dimensional Fenwick tree over a fixed grid.
+// Ranges are half-open in both dimensions.
+type Fenwick2D[T Number] struct {
+	rows int
+	cols int
+	tree [][]T
+	mu   sync.RWMutex
+}
+
+// New2D creates a new two-dimensional Fenwick tree of rows by cols zero values.
+func New2D[T Number](rows, cols int) *Fenwick2D[T] {
+	tree := make([][]T, rows+1)
+	for i := range tree {
+		tree[i] = make([]T, cols+1)
+	}
+	return &Fenwick2D[T]{rows: rows, cols: cols, tree: tree}
+}
+
+// add adds delta to the value at (row, col).
+func (f *Fenwick2D[T]) add(row, col int, delta T) {
+	for i := row + 1; i <= f.rows; i += i & -i {
+		for j := col + 1; j <= f.cols; j += j & -j {
+			f.tree[i][j] += delta
+		}
+	}
+}
+
+// prefixSum returns the sum of the values in the first rows and cols.
+func (f *Fenwick2D[T]) prefixSum(rows, cols int) T {
+	var sum T
+	for i := rows; i > 0; i -= i & -i {
+		for j := cols; j > 0; j -= j & -j {
+			sum += f.tree[i][j]
+		}
+	}
+	return sum
+}
+
+// Rows returns the number of rows in the grid.
+func (f *Fenwick2D[T]) Rows() int {
+	return f.rows
+}
+
+// Cols returns the number of columns in the grid.
+func (f *Fenwick2D[T]) Cols() int {
+	return f.cols
+}
+
+// Add adds delta to the value at (row, col) in a concurrency-safe manner.
+func (f *Fenwick2D[T]) Add(row, col int, delta T) bool {
+	if row < 0 || row >= f.rows || col < 0 || col >= f.cols {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.add(row, col, delta)
+	return true
+}
+
+// PrefixSum returns the sum of the values in the first rows and cols in a concurrency-safe manner.
+func (f *Fenwick2D[T]) PrefixSum(rows, cols int) (T, bool) {
+	if rows < 0 || rows > f.rows || cols < 0 || cols > f.cols {
+		return 0, false
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.prefixSum(rows, cols), true
+}
+
+// RangeSum returns the sum of the values in rows [fromRow, toRow) and columns [fromCol, toCol)
+// in a concurrency-safe manner.
+func (f *Fenwick2D[T]) RangeSum(fromRow, fromCol, toRow, toCol int) (T, bool) {
+	if fromRow < 0 || toRow > f.rows || fromRow > toRow || fromCol < 0 || toCol > f.cols || fromCol > toCol {
+		return 0, false
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.prefixSum(toRow, toCol) - f.prefixSum(fromRow, toCol) -
+		f.prefixSum(toRow, fromCol) + f.prefixSum(fromRow, fromCol), true
+}
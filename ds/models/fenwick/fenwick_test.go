@@ -0,0 +1,106 @@
+package fenwick_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/fenwick"
+)
+
+func TestFenwickRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	reference := make([]int, 64)
+	for i := range reference {
+		reference[i] = r.Intn(10)
+	}
+	f := fenwick.FromSlice(reference)
+	for step := 0; step < 2000; step++ {
+		i := r.Intn(len(reference))
+		switch r.Intn(3) {
+		case 0:
+			delta := r.Intn(10)
+			f.Add(i, delta)
+			reference[i] += delta
+		case 1:
+			value := r.Intn(10)
+			f.Set(i, value)
+			reference[i] = value
+		default:
+			j := i + r.Intn(len(reference)-i+1)
+			expected := 0
+			for k := i; k < j; k++ {
+				expected += reference[k]
+			}
+			if got, _ := f.RangeSum(i, j); got != expected {
+				t.Fatalf("RangeSum(%d, %d): expected %d, got %d", i, j, expected, got)
+			}
+		}
+	}
+}
+
+func TestFenwickLowerBound(t *testing.T) {
+	f := fenwick.FromSlice([]float64{1, 0, 2.5, 0, 4})
+	tests := []struct {
+		target   float64
+		expected int
+		ok       bool
+	}{
+		{target: 0, expected: 0, ok: true},
+		{target: 1, expected: 0, ok: true},
+		{target: 1.5, expected: 2, ok: true},
+		{target: 3.5, expected: 2, ok: true},
+		{target: 7.5, expected: 4, ok: true},
+		{target: 8, expected: -1, ok: false},
+	}
+	for _, test := range tests {
+		if got, ok := f.LowerBound(test.target); got != test.expected || ok != test.ok {
+			t.Errorf("LowerBound(%g): expected (%d, %t), got (%d, %t)", test.target, test.expected, test.ok, got, ok)
+		}
+	}
+	if _, ok := fenwick.New[int](0).LowerBound(1); ok {
+		t.Error("Expected LowerBound on an empty tree to return false")
+	}
+}
+
+func TestFenwickInvalidIndices(t *testing.T) {
+	f := fenwick.New[int](3)
+	if f.Add(3, 1) || f.Add(-1, 1) || f.Set(3, 1) {
+		t.Error("Expected updates out of range to fail")
+	}
+	if _, ok := f.PrefixSum(4); ok {
+		t.Error("Expected PrefixSum past the end to fail")
+	}
+	if _, ok := f.RangeSum(2, 1); ok {
+		t.Error("Expected inverted RangeSum to fail")
+	}
+}
+
+func TestFenwick2D(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rows, cols := 8, 12
+	reference := make([][]int, rows)
+	for i := range reference {
+		reference[i] = make([]int, cols)
+	}
+	f := fenwick.New2D[int](rows, cols)
+	for step := 0; step < 500; step++ {
+		row, col, delta := r.Intn(rows), r.Intn(cols), r.Intn(10)
+		f.Add(row, col, delta)
+		reference[row][col] += delta
+
+		fromRow, fromCol := r.Intn(rows), r.Intn(cols)
+		toRow, toCol := fromRow+r.Intn(rows-fromRow+1), fromCol+r.Intn(cols-fromCol+1)
+		expected := 0
+		for i := fromRow; i < toRow; i++ {
+			for j := fromCol; j < toCol; j++ {
+				expected += reference[i][j]
+			}
+		}
+		if got, _ := f.RangeSum(fromRow, fromCol, toRow, toCol); got != expected {
+			t.Fatalf("RangeSum: expected %d, got %d", expected, got)
+		}
+	}
+	if f.Add(rows, 0, 1) {
+		t.Error("Expected Add out of range to fail")
+	}
+}
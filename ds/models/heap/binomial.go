@@ -0,0 +1,251 @@
+package heap
+
+import (
+	"cmp"
+	"sync"
+)
+
+// BinomialNode is a handle to an element of a BinomialHeap, used to change or delete it.
+type BinomialNode[T any] struct {
+	value T
+	tree  *binomialTree[T]
+}
+
+// Value returns the element held by the node.
+func (n *BinomialNode[T]) Value() T {
+	return n.value
+}
+
+// binomialTree is a position in a binomial tree. Elements move between positions as they
+// rise, so each position refers to the handle of its current element.
+type binomialTree[T any] struct {
+	item                   *BinomialNode[T]
+	parent, child, sibling *binomialTree[T]
+	degree                 int
+}
+
+// The BinomialHeap struct represents a binomial heap ordered by a less function.
+// Push, Pop, Meld, DecreaseKey and Delete take O(log n) time.
+type BinomialHeap[T any] struct {
+	// roots is the list of trees linked by sibling, in increasing degree.
+	roots  *binomialTree[T]
+	length int
+	less   func(a, b T) bool
+	mu     sync.RWMutex
+}
+
+// NewBinomial creates a new binomial min-heap of naturally ordered elements.
+func NewBinomial[T cmp.Ordered]() *BinomialHeap[T] {
+	return NewBinomialWithLess(cmp.Less[T])
+}
+
+// NewBinomialWithLess creates a new binomial heap ordered by less.
+func NewBinomialWithLess[T any](less func(a, b T) bool) *BinomialHeap[T] {
+	return &BinomialHeap[T]{less: less}
+}
+
+// mergeRoots merges two root lists into one ordered by degree.
+func mergeRoots[T any](a, b *binomialTree[T]) *binomialTree[T] {
+	var head binomialTree[T]
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+	return head.sibling
+}
+
+// union merges the root list of other into the heap, linking trees of equal degree.
+func (h *BinomialHeap[T]) union(other *binomialTree[T]) {
+	roots := mergeRoots(h.roots, other)
+	if roots == nil {
+		h.roots = nil
+		return
+	}
+	var prev *binomialTree[T]
+	x := roots
+	next := x.sibling
+	for next != nil {
+		if x.degree != next.degree || (next.sibling != nil && next.sibling.degree == x.degree) {
+			prev, x = x, next
+		} else if !h.less(next.item.value, x.item.value) {
+			x.sibling = next.sibling
+			link(next, x)
+		} else {
+			if prev == nil {
+				roots = next
+			} else {
+				prev.sibling = next
+			}
+			link(x, next)
+			x = next
+		}
+		next = x.sibling
+	}
+	h.roots = roots
+}
+
+// link makes the tree child the first child of the tree parent, whose degrees are equal.
+func link[T any](child, parent *binomialTree[T]) {
+	child.parent = parent
+	child.sibling = parent.child
+	parent.child = child
+	parent.degree++
+}
+
+// top returns the root holding the top element and the root before it in the list.
+func (h *BinomialHeap[T]) top() (*binomialTree[T], *binomialTree[T]) {
+	var best, bestPrev, prev *binomialTree[T]
+	for x := h.roots; x != nil; prev, x = x, x.sibling {
+		if best == nil || h.less(x.item.value, best.item.value) {
+			best, bestPrev = x, prev
+		}
+	}
+	return best, bestPrev
+}
+
+// removeRoot removes the root from the root list and merges its children back in.
+func (h *BinomialHeap[T]) removeRoot(root, prev *binomialTree[T]) {
+	if prev == nil {
+		h.roots = root.sibling
+	} else {
+		prev.sibling = root.sibling
+	}
+	var children *binomialTree[T]
+	for child := root.child; child != nil; {
+		next := child.sibling
+		child.parent = nil
+		child.sibling = children
+		children = child
+		child = next
+	}
+	h.union(children)
+	root.item.tree = nil
+	h.length--
+}
+
+// swapItems exchanges the elements held by two positions.
+func swapItems[T any](a, b *binomialTree[T]) {
+	a.item, b.item = b.item, a.item
+	a.item.tree = a
+	b.item.tree = b
+}
+
+// bubbleUp moves the element at x towards the root while force is set or it has a higher
+// priority than its parent, and returns its final position.
+func (h *BinomialHeap[T]) bubbleUp(x *binomialTree[T], force bool) *binomialTree[T] {
+	for x.parent != nil && (force || h.less(x.item.value, x.parent.item.value)) {
+		swapItems(x, x.parent)
+		x = x.parent
+	}
+	return x
+}
+
+// Push adds an element to the heap in a concurrency-safe manner.
+func (h *BinomialHeap[T]) Push(data T) {
+	h.Insert(data)
+}
+
+// Insert adds an element to the heap in a concurrency-safe manner and returns its node.
+func (h *BinomialHeap[T]) Insert(data T) *BinomialNode[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := &BinomialNode[T]{value: data}
+	n.tree = &binomialTree[T]{item: n}
+	h.union(n.tree)
+	h.length++
+	return n
+}
+
+// Pop removes and returns the top element of the heap in a concurrency-safe manner.
+func (h *BinomialHeap[T]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	root, prev := h.top()
+	if root == nil {
+		return zeroValue[T](), false
+	}
+	item := root.item
+	h.removeRoot(root, prev)
+	return item.value, true
+}
+
+// Peek returns the top element of the heap without removing it in a concurrency-safe manner.
+func (h *BinomialHeap[T]) Peek() (T, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	root, _ := h.top()
+	if root == nil {
+		return zeroValue[T](), false
+	}
+	return root.item.value, true
+}
+
+// DecreaseKey replaces the element of a queued node with one that is not lower in priority
+// in a concurrency-safe manner. It returns false if the node was removed or the new
+// element would move it away from the top. The node must belong to h or to a heap melded into it.
+func (h *BinomialHeap[T]) DecreaseKey(n *BinomialNode[T], data T) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if n.tree == nil || h.less(n.value, data) {
+		return false
+	}
+	n.value = data
+	h.bubbleUp(n.tree, false)
+	return true
+}
+
+// Delete removes a queued node from the heap in a concurrency-safe manner.
+// It returns false if the node was already removed. The node must belong to h or to a
+// heap melded into it.
+func (h *BinomialHeap[T]) Delete(n *BinomialNode[T]) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if n.tree == nil {
+		return false
+	}
+	root := h.bubbleUp(n.tree, true)
+	var prev *binomialTree[T]
+	for x := h.roots; x != root; x = x.sibling {
+		prev = x
+	}
+	h.removeRoot(root, prev)
+	return true
+}
+
+// Meld moves every element of other into h in O(log n) in a concurrency-safe manner, leaving
+// other empty. Nodes of other stay valid as nodes of h. Both heaps must use the same order.
+func (h *BinomialHeap[T]) Meld(other *BinomialHeap[T]) {
+	other.mu.Lock()
+	roots, length := other.roots, other.length
+	other.roots, other.length = nil, 0
+	other.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.union(roots)
+	h.length += length
+}
+
+// IsEmpty returns true if the heap is empty in a concurrency-safe manner.
+func (h *BinomialHeap[T]) IsEmpty() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.roots == nil
+}
+
+// Length returns the number of elements in the heap in a concurrency-safe manner.
+func (h *BinomialHeap[T]) Length() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.length
+}
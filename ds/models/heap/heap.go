@@ -0,0 +1,142 @@
+// Description: This package contains the implementation of a typed binary heap.
+package heap
+
+import (
+	"cmp"
+	"sync"
+)
+
+// The Heap struct represents a binary heap ordered by a less function.
+// The element for which less holds against every other element is at the top.
+type Heap[T any] struct {
+	data []T
+	less func(a, b T) bool
+	mu   sync.RWMutex
+}
+
+func zeroValue[T any]() T {
+	var zero T
+	return zero
+}
+
+// New creates a new min-heap of naturally ordered elements.
+func New[T cmp.Ordered]() *Heap[T] {
+	return NewWithLess(cmp.Less[T])
+}
+
+// NewWithLess creates a new heap ordered by less.
+func NewWithLess[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// FromSlice creates a new heap ordered by less over a copy of values in O(n).
+func FromSlice[T any](values []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{data: append([]T(nil), values...), less: less}
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// up moves the element at index i towards the root until the heap property holds.
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.data[i], h.data[parent]) {
+			break
+		}
+		h.data[i], h.data[parent] = h.data[parent], h.data[i]
+		i = parent
+	}
+}
+
+// down moves the element at index i towards the leaves until the heap property holds.
+func (h *Heap[T]) down(i int) {
+	n := len(h.data)
+	for {
+		smallest := i
+		if left := 2*i + 1; left < n && h.less(h.data[left], h.data[smallest]) {
+			smallest = left
+		}
+		if right := 2*i + 2; right < n && h.less(h.data[right], h.data[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		h.data[i], h.data[smallest] = h.data[smallest], h.data[i]
+		i = smallest
+	}
+}
+
+// push adds an element to the heap.
+func (h *Heap[T]) push(data T) {
+	h.data = append(h.data, data)
+	h.up(len(h.data) - 1)
+}
+
+// pop removes and returns the top element of the heap.
+func (h *Heap[T]) pop() (T, bool) {
+	if len(h.data) == 0 {
+		return zeroValue[T](), false
+	}
+	top := h.data[0]
+	last := len(h.data) - 1
+	h.data[0] = h.data[last]
+	h.data[last] = zeroValue[T]()
+	h.data = h.data[:last]
+	h.down(0)
+	return top, true
+}
+
+// Push adds an element to the heap in a concurrency-safe manner.
+func (h *Heap[T]) Push(data T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.push(data)
+}
+
+// Pop removes and returns the top element of the heap in a concurrency-safe manner.
+func (h *Heap[T]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.pop()
+}
+
+// Peek returns the top element of the heap without removing it in a concurrency-safe manner.
+func (h *Heap[T]) Peek() (T, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.data) == 0 {
+		return zeroValue[T](), false
+	}
+	return h.data[0], true
+}
+
+// PushPop adds an element and then removes and returns the top element in a
+// concurrency-safe manner. It is more efficient than Push followed by Pop.
+func (h *Heap[T]) PushPop(data T) T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.data) == 0 || !h.less(h.data[0], data) {
+		return data
+	}
+	top := h.data[0]
+	h.data[0] = data
+	h.down(0)
+	return top
+}
+
+// IsEmpty returns true if the heap is empty in a concurrency-safe manner.
+func (h *Heap[T]) IsEmpty() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.data) == 0
+}
+
+// Length returns the number of elements in the heap in a concurrency-safe manner.
+func (h *Heap[T]) Length() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.data)
+}
@@ -47,3 +47,61 @@ func TestHeap(t *testing.T) {
 		}
 	}
 }
+
+func TestTypedHeap(t *testing.T) {
+	tests := []struct {
+		name     string
+		heap     *Heap[int]
+		expected []int
+	}{
+		{
+			name:     "Min-heap",
+			heap:     New[int](),
+			expected: []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:     "Max-heap",
+			heap:     NewWithLess(func(a, b int) bool { return a > b }),
+			expected: []int{6, 5, 4, 3, 2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range []int{2, 1, 5, 3, 4, 6} {
+				tt.heap.Push(v)
+			}
+			if top, ok := tt.heap.Peek(); !ok || top != tt.expected[0] {
+				t.Errorf("Expected Peek to return %d, got %d", tt.expected[0], top)
+			}
+			for _, expected := range tt.expected {
+				if popped, ok := tt.heap.Pop(); !ok || popped != expected {
+					t.Errorf("Expected %d, got %d", expected, popped)
+				}
+			}
+			if _, ok := tt.heap.Pop(); ok || !tt.heap.IsEmpty() {
+				t.Error("Expected heap to be empty")
+			}
+		})
+	}
+}
+
+func TestTypedHeapFromSlice(t *testing.T) {
+	h := FromSlice([]int{9, 4, 7, 1, 8, 2}, func(a, b int) bool { return a < b })
+	if h.Length() != 6 {
+		t.Errorf("Expected length 6, got %d", h.Length())
+	}
+	if top := h.PushPop(0); top != 0 {
+		t.Errorf("Expected PushPop of a new minimum to return it, got %d", top)
+	}
+	if top := h.PushPop(5); top != 1 {
+		t.Errorf("Expected PushPop to return 1, got %d", top)
+	}
+	previous := -1
+	for !h.IsEmpty() {
+		v, _ := h.Pop()
+		if v < previous {
+			t.Errorf("Heap property violated: %d after %d", v, previous)
+		}
+		previous = v
+	}
+}
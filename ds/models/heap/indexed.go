@@ -0,0 +1,190 @@
+package heap
+
+import (
+	"cmp"
+	"sync"
+)
+
+// indexedItem is a key and its priority in an IndexedPriorityQueue.
+type indexedItem[K comparable, P any] struct {
+	key      K
+	priority P
+}
+
+// The IndexedPriorityQueue struct represents a binary heap of unique keys ordered by their
+// priorities. It tracks the position of every key, so the priority of a queued key can be
+// changed or the key removed in O(log n).
+type IndexedPriorityQueue[K comparable, P any] struct {
+	items []indexedItem[K, P]
+	index map[K]int
+	less  func(a, b P) bool
+	mu    sync.RWMutex
+}
+
+// NewIndexed creates a new indexed priority queue popping the smallest priority first.
+func NewIndexed[K comparable, P cmp.Ordered]() *IndexedPriorityQueue[K, P] {
+	return NewIndexedWithLess[K](cmp.Less[P])
+}
+
+// NewIndexedWithLess creates a new indexed priority queue ordered by less on priorities.
+func NewIndexedWithLess[K comparable, P any](less func(a, b P) bool) *IndexedPriorityQueue[K, P] {
+	return &IndexedPriorityQueue[K, P]{
+		index: make(map[K]int),
+		less:  less,
+	}
+}
+
+// swap exchanges the items at indices i and j and records their new positions.
+func (q *IndexedPriorityQueue[K, P]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.index[q.items[i].key] = i
+	q.index[q.items[j].key] = j
+}
+
+// up moves the item at index i towards the root until the heap property holds.
+func (q *IndexedPriorityQueue[K, P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i].priority, q.items[parent].priority) {
+			break
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the item at index i towards the leaves until the heap property holds.
+func (q *IndexedPriorityQueue[K, P]) down(i int) {
+	n := len(q.items)
+	for {
+		smallest := i
+		if left := 2*i + 1; left < n && q.less(q.items[left].priority, q.items[smallest].priority) {
+			smallest = left
+		}
+		if right := 2*i + 2; right < n && q.less(q.items[right].priority, q.items[smallest].priority) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		q.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// fix restores the heap property after the priority at index i changed.
+func (q *IndexedPriorityQueue[K, P]) fix(i int) {
+	q.up(i)
+	q.down(q.index[q.items[i].key])
+}
+
+// removeAt removes and returns the item at index i.
+func (q *IndexedPriorityQueue[K, P]) removeAt(i int) indexedItem[K, P] {
+	last := len(q.items) - 1
+	if i != last {
+		q.swap(i, last)
+	}
+	item := q.items[last]
+	q.items[last] = indexedItem[K, P]{}
+	q.items = q.items[:last]
+	delete(q.index, item.key)
+	if i != last {
+		q.fix(i)
+	}
+	return item
+}
+
+// Push adds the key with the priority in a concurrency-safe manner.
+// It returns false if the key is already queued; use Update to change its priority.
+func (q *IndexedPriorityQueue[K, P]) Push(key K, priority P) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.index[key]; ok {
+		return false
+	}
+	q.items = append(q.items, indexedItem[K, P]{key: key, priority: priority})
+	q.index[key] = len(q.items) - 1
+	q.up(len(q.items) - 1)
+	return true
+}
+
+// Pop removes and returns the key with the top priority in a concurrency-safe manner.
+func (q *IndexedPriorityQueue[K, P]) Pop() (K, P, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return zeroValue[K](), zeroValue[P](), false
+	}
+	item := q.removeAt(0)
+	return item.key, item.priority, true
+}
+
+// Peek returns the key with the top priority without removing it in a concurrency-safe manner.
+func (q *IndexedPriorityQueue[K, P]) Peek() (K, P, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if len(q.items) == 0 {
+		return zeroValue[K](), zeroValue[P](), false
+	}
+	return q.items[0].key, q.items[0].priority, true
+}
+
+// Update changes the priority of a queued key in a concurrency-safe manner.
+// It returns false if the key is not queued.
+func (q *IndexedPriorityQueue[K, P]) Update(key K, priority P) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	i, ok := q.index[key]
+	if !ok {
+		return false
+	}
+	q.items[i].priority = priority
+	q.fix(i)
+	return true
+}
+
+// Remove removes the key from the queue in a concurrency-safe manner.
+// It returns false if the key is not queued.
+func (q *IndexedPriorityQueue[K, P]) Remove(key K) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	i, ok := q.index[key]
+	if !ok {
+		return false
+	}
+	q.removeAt(i)
+	return true
+}
+
+// Contains returns true if the key is queued in a concurrency-safe manner.
+func (q *IndexedPriorityQueue[K, P]) Contains(key K) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	_, ok := q.index[key]
+	return ok
+}
+
+// PriorityOf returns the priority of a queued key in a concurrency-safe manner.
+func (q *IndexedPriorityQueue[K, P]) PriorityOf(key K) (P, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	i, ok := q.index[key]
+	if !ok {
+		return zeroValue[P](), false
+	}
+	return q.items[i].priority, true
+}
+
+// IsEmpty returns true if the queue is empty in a concurrency-safe manner.
+func (q *IndexedPriorityQueue[K, P]) IsEmpty() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return len(q.items) == 0
+}
+
+// Length returns the number of queued keys in a concurrency-safe manner.
+func (q *IndexedPriorityQueue[K, P]) Length() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return len(q.items)
+}
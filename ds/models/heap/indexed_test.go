@@ -0,0 +1,110 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkIndexed verifies the heap property and that every key maps to its position.
+func checkIndexed[K comparable, P any](t *testing.T, q *IndexedPriorityQueue[K, P]) {
+	t.Helper()
+	if len(q.index) != len(q.items) {
+		t.Fatalf("Expected %d indexed keys, got %d", len(q.items), len(q.index))
+	}
+	for i, item := range q.items {
+		if q.index[item.key] != i {
+			t.Fatalf("Expected key %v at index %d, got %d", item.key, i, q.index[item.key])
+		}
+		if i > 0 && q.less(item.priority, q.items[(i-1)/2].priority) {
+			t.Fatalf("Heap property violated at index %d", i)
+		}
+	}
+}
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	q := NewIndexed[string, int]()
+	q.Push("a", 5)
+	q.Push("b", 3)
+	q.Push("c", 8)
+	if q.Push("a", 1) {
+		t.Error("Expected Push of a queued key to fail")
+	}
+	if key, priority, ok := q.Peek(); !ok || key != "b" || priority != 3 {
+		t.Errorf("Expected b with priority 3 on top, got %s with %d", key, priority)
+	}
+	q.Update("c", 1)
+	if key, _, _ := q.Peek(); key != "c" {
+		t.Errorf("Expected decreased c on top, got %s", key)
+	}
+	q.Update("c", 10)
+	if priority, ok := q.PriorityOf("c"); !ok || priority != 10 {
+		t.Errorf("Expected priority 10, got %d", priority)
+	}
+	if !q.Remove("b") || q.Remove("b") || q.Contains("b") {
+		t.Error("Expected b to be removed once")
+	}
+	if q.Update("missing", 1) {
+		t.Error("Expected Update of a missing key to fail")
+	}
+	expected := []string{"a", "c"}
+	for _, key := range expected {
+		if popped, _, ok := q.Pop(); !ok || popped != key {
+			t.Errorf("Expected %s, got %s", key, popped)
+		}
+	}
+	if _, _, ok := q.Pop(); ok || !q.IsEmpty() {
+		t.Error("Expected queue to be empty")
+	}
+}
+
+func TestIndexedPriorityQueueRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	q := NewIndexedWithLess[int](func(a, b float64) bool { return a > b })
+	reference := make(map[int]float64)
+	for n := 0; n < 5000; n++ {
+		key := rng.Intn(200)
+		priority := rng.Float64()
+		_, queued := reference[key]
+		switch rng.Intn(4) {
+		case 0:
+			if q.Push(key, priority) == queued {
+				t.Fatalf("Unexpected result pushing %d", key)
+			}
+			if !queued {
+				reference[key] = priority
+			}
+		case 1:
+			if q.Update(key, priority) != queued {
+				t.Fatalf("Unexpected result updating %d", key)
+			}
+			if queued {
+				reference[key] = priority
+			}
+		case 2:
+			if q.Remove(key) != queued {
+				t.Fatalf("Unexpected result removing %d", key)
+			}
+			delete(reference, key)
+		case 3:
+			popped, priority, ok := q.Pop()
+			if ok != (len(reference) > 0) {
+				t.Fatal("Unexpected result popping")
+			}
+			for _, p := range reference {
+				if p > priority {
+					t.Fatalf("Expected the largest priority, %g is larger than %g", p, priority)
+				}
+			}
+			delete(reference, popped)
+		}
+		if n%100 == 0 {
+			checkIndexed(t, q)
+		}
+	}
+	checkIndexed(t, q)
+	for key, priority := range reference {
+		if p, ok := q.PriorityOf(key); !ok || p != priority {
+			t.Fatalf("Expected key %d with priority %g, got %g", key, priority, p)
+		}
+	}
+}
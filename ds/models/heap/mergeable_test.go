@@ -0,0 +1,291 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/mmygods/gods/ds/collections"
+)
+
+var (
+	_ collections.PriorityQueue[int] = (*Heap[int])(nil)
+	_ collections.PriorityQueue[int] = (*PairingHeap[int])(nil)
+	_ collections.PriorityQueue[int] = (*BinomialHeap[int])(nil)
+)
+
+// mergeableHeap is the API shared by PairingHeap and BinomialHeap with node handles of type N.
+type mergeableHeap[N any] interface {
+	collections.PriorityQueue[int]
+	Insert(data int) N
+	DecreaseKey(n N, data int) bool
+	Delete(n N) bool
+}
+
+// checkBinomial verifies that every tree of the heap is heap-ordered, that degrees match the
+// number of children and that every element refers to its position.
+func checkBinomial(t *testing.T, h *BinomialHeap[int]) {
+	t.Helper()
+	var count func(x *binomialTree[int]) int
+	count = func(x *binomialTree[int]) int {
+		if x.item.tree != x {
+			t.Fatalf("Expected %d to refer to its position", x.item.value)
+		}
+		n, children := 1, 0
+		for c := x.child; c != nil; c = c.sibling {
+			if c.parent != x || h.less(c.item.value, x.item.value) {
+				t.Fatalf("Heap order violated below %d", x.item.value)
+			}
+			n += count(c)
+			children++
+		}
+		if children != x.degree || n != 1<<x.degree {
+			t.Fatalf("Expected a tree of degree %d, got %d children and %d nodes", x.degree, children, n)
+		}
+		return n
+	}
+	total, degree := 0, -1
+	for x := h.roots; x != nil; x = x.sibling {
+		if x.degree <= degree {
+			t.Fatalf("Expected root degrees to increase, got %d after %d", x.degree, degree)
+		}
+		degree = x.degree
+		total += count(x)
+	}
+	if total != h.length {
+		t.Fatalf("Expected %d elements, got %d", h.length, total)
+	}
+}
+
+// checkPairing verifies that the tree of the heap is heap-ordered and correctly linked.
+func checkPairing(t *testing.T, h *PairingHeap[int]) {
+	t.Helper()
+	var count func(x *PairingNode[int]) int
+	count = func(x *PairingNode[int]) int {
+		if !x.queued {
+			t.Fatalf("Expected %d to be queued", x.value)
+		}
+		n := 1
+		prev := x
+		for c := x.child; c != nil; prev, c = c, c.sibling {
+			if c.prev != prev || h.less(c.value, x.value) {
+				t.Fatalf("Heap order or links violated below %d", x.value)
+			}
+			n += count(c)
+		}
+		return n
+	}
+	total := 0
+	if h.root != nil {
+		if h.root.prev != nil || h.root.sibling != nil {
+			t.Fatal("Expected the root to have no siblings")
+		}
+		total = count(h.root)
+	}
+	if total != h.length {
+		t.Fatalf("Expected %d elements, got %d", h.length, total)
+	}
+}
+
+// testMergeable runs random operations on h and compares the results with an unordered
+// reference. value returns the element of a node and queued reports whether it is in the heap.
+func testMergeable[N any](t *testing.T, h mergeableHeap[N], value func(N) int, queued func(N) bool, check func()) {
+	rng := rand.New(rand.NewSource(1))
+	var nodes []N
+	var reference []int
+	remove := func(data int) {
+		i := slices.Index(reference, data)
+		reference = slices.Delete(reference, i, i+1)
+	}
+	for n := 0; n < 5000; n++ {
+		switch op := rng.Intn(6); {
+		case op == 0:
+			data := rng.Intn(1000)
+			h.Push(data)
+			reference = append(reference, data)
+		case op == 1:
+			data := rng.Intn(1000)
+			nodes = append(nodes, h.Insert(data))
+			reference = append(reference, data)
+		case op == 2 && len(nodes) > 0:
+			node := nodes[rng.Intn(len(nodes))]
+			old := value(node)
+			data := old - rng.Intn(100)
+			if !h.DecreaseKey(node, data) {
+				t.Fatalf("Expected DecreaseKey from %d to %d to succeed", old, data)
+			}
+			if h.DecreaseKey(node, data+1) {
+				t.Fatal("Expected DecreaseKey to a larger element to fail")
+			}
+			reference[slices.Index(reference, old)] = data
+		case op == 3 && len(nodes) > 0:
+			i := rng.Intn(len(nodes))
+			data := value(nodes[i])
+			if !h.Delete(nodes[i]) || h.Delete(nodes[i]) {
+				t.Fatalf("Expected %d to be deleted once", data)
+			}
+			if h.DecreaseKey(nodes[i], data-1) {
+				t.Fatal("Expected DecreaseKey of a deleted node to fail")
+			}
+			nodes = slices.Delete(nodes, i, i+1)
+			remove(data)
+		case op >= 4:
+			data, ok := h.Pop()
+			if ok != (len(reference) > 0) {
+				t.Fatal("Unexpected result popping")
+			}
+			if !ok {
+				continue
+			}
+			if smallest := slices.Min(reference); data != smallest {
+				t.Fatalf("Expected %d, got %d", smallest, data)
+			}
+			remove(data)
+			nodes = slices.DeleteFunc(nodes, func(n N) bool { return !queued(n) })
+		}
+		if h.Length() != len(reference) || h.IsEmpty() != (len(reference) == 0) {
+			t.Fatalf("Expected length %d, got %d", len(reference), h.Length())
+		}
+		if n%100 == 0 {
+			check()
+		}
+	}
+	check()
+	for range reference {
+		if _, ok := h.Pop(); !ok {
+			t.Fatal("Expected the heap to hold every remaining element")
+		}
+	}
+	if _, ok := h.Peek(); ok || !h.IsEmpty() {
+		t.Error("Expected the heap to be empty")
+	}
+}
+
+func TestPairingHeap(t *testing.T) {
+	h := NewPairing[int]()
+	testMergeable[*PairingNode[int]](t, h,
+		func(n *PairingNode[int]) int { return n.Value() },
+		func(n *PairingNode[int]) bool { return n.queued },
+		func() { checkPairing(t, h) })
+}
+
+func TestBinomialHeap(t *testing.T) {
+	h := NewBinomial[int]()
+	testMergeable[*BinomialNode[int]](t, h,
+		func(n *BinomialNode[int]) int { return n.Value() },
+		func(n *BinomialNode[int]) bool { return n.tree != nil },
+		func() { checkBinomial(t, h) })
+}
+
+func TestPairingHeapMeld(t *testing.T) {
+	a := NewPairingWithLess(func(a, b string) bool { return a > b })
+	b := NewPairingWithLess(func(a, b string) bool { return a > b })
+	a.Push("b")
+	a.Push("d")
+	node := b.Insert("a")
+	b.Push("c")
+	a.Meld(b)
+	if !b.IsEmpty() || b.Length() != 0 || a.Length() != 4 {
+		t.Fatalf("Expected all elements in a, got %d and %d", a.Length(), b.Length())
+	}
+	if !a.DecreaseKey(node, "e") {
+		t.Error("Expected a node of a melded heap to stay valid")
+	}
+	for _, expected := range []string{"e", "d", "c", "b"} {
+		if data, ok := a.Pop(); !ok || data != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	}
+}
+
+func TestBinomialHeapMeld(t *testing.T) {
+	a, b := NewBinomial[int](), NewBinomial[int]()
+	var nodes []*BinomialNode[int]
+	for i := 0; i < 50; i++ {
+		a.Push(2 * i)
+		nodes = append(nodes, b.Insert(2*i+1))
+	}
+	a.Meld(b)
+	checkBinomial(t, a)
+	if !b.IsEmpty() || a.Length() != 100 {
+		t.Fatalf("Expected all elements in a, got %d and %d", a.Length(), b.Length())
+	}
+	if !a.Delete(nodes[0]) || !a.DecreaseKey(nodes[49], -1) {
+		t.Error("Expected nodes of a melded heap to stay valid")
+	}
+	checkBinomial(t, a)
+	if data, _ := a.Pop(); data != -1 {
+		t.Errorf("Expected -1, got %d", data)
+	}
+	for i := 0; i < 2; i++ {
+		if data, _ := a.Pop(); data != 2*i {
+			t.Errorf("Expected %d, got %d", 2*i, data)
+		}
+	}
+}
+
+func benchmarkPushPop(b *testing.B, h collections.PriorityQueue[int]) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		h.Push(rng.Int())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Push(rng.Int())
+		h.Pop()
+	}
+}
+
+func BenchmarkHeapPushPop(b *testing.B) {
+	benchmarkPushPop(b, New[int]())
+}
+
+func BenchmarkPairingHeapPushPop(b *testing.B) {
+	benchmarkPushPop(b, NewPairing[int]())
+}
+
+func BenchmarkBinomialHeapPushPop(b *testing.B) {
+	benchmarkPushPop(b, NewBinomial[int]())
+}
+
+func BenchmarkHeapMeld(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		x, y := New[int](), New[int]()
+		for j := 0; j < 1000; j++ {
+			x.Push(j)
+			y.Push(-j)
+		}
+		b.StartTimer()
+		for !y.IsEmpty() {
+			data, _ := y.Pop()
+			x.Push(data)
+		}
+	}
+}
+
+func BenchmarkPairingHeapMeld(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		x, y := NewPairing[int](), NewPairing[int]()
+		for j := 0; j < 1000; j++ {
+			x.Push(j)
+			y.Push(-j)
+		}
+		b.StartTimer()
+		x.Meld(y)
+	}
+}
+
+func BenchmarkBinomialHeapMeld(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		x, y := NewBinomial[int](), NewBinomial[int]()
+		for j := 0; j < 1000; j++ {
+			x.Push(j)
+			y.Push(-j)
+		}
+		b.StartTimer()
+		x.Meld(y)
+	}
+}
@@ -0,0 +1,281 @@
+package heap
+
+import (
+	"cmp"
+	"math/bits"
+	"sync"
+)
+
+// The MinMaxHeap struct represents a double-ended priority queue ordered by a less function.
+// Elements on even levels are smaller than their descendants and elements on odd levels are
+// larger, so both ends can be read in O(1) and removed in O(log n).
+//
+// A bounded heap holds at most a fixed number of elements and evicts its largest element
+// when an element is pushed while it is full, which keeps the smallest elements seen so far.
+type MinMaxHeap[T any] struct {
+	data     []T
+	less     func(a, b T) bool
+	capacity int
+	mu       sync.RWMutex
+}
+
+// NewMinMax creates a new unbounded min-max heap of naturally ordered elements.
+func NewMinMax[T cmp.Ordered]() *MinMaxHeap[T] {
+	return NewMinMaxWithLess(cmp.Less[T])
+}
+
+// NewMinMaxWithLess creates a new unbounded min-max heap ordered by less.
+func NewMinMaxWithLess[T any](less func(a, b T) bool) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{less: less}
+}
+
+// NewBoundedMinMax creates a new min-max heap of naturally ordered elements holding at most
+// capacity elements. It panics if capacity is less than 1.
+func NewBoundedMinMax[T cmp.Ordered](capacity int) *MinMaxHeap[T] {
+	return NewBoundedMinMaxWithLess(capacity, cmp.Less[T])
+}
+
+// NewBoundedMinMaxWithLess creates a new min-max heap ordered by less holding at most
+// capacity elements. It panics if capacity is less than 1.
+func NewBoundedMinMaxWithLess[T any](capacity int, less func(a, b T) bool) *MinMaxHeap[T] {
+	if capacity < 1 {
+		panic("heap: capacity must be at least 1")
+	}
+	return &MinMaxHeap[T]{data: make([]T, 0, capacity), less: less, capacity: capacity}
+}
+
+// isMinLevel returns true if index i is on a level ordered as a min-heap.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// before reports whether a must be closer to the root than b on the level of index i.
+func (h *MinMaxHeap[T]) before(i int, a, b T) bool {
+	if isMinLevel(i) {
+		return h.less(a, b)
+	}
+	return h.less(b, a)
+}
+
+// swap exchanges the elements at indices i and j.
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
+
+// up moves the element at index i towards the root until the heap property holds.
+func (h *MinMaxHeap[T]) up(i int) {
+	if i == 0 {
+		return
+	}
+	if parent := (i - 1) / 2; h.before(parent, h.data[i], h.data[parent]) {
+		h.swap(i, parent)
+		i = parent
+	}
+	for i > 2 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if !h.before(i, h.data[i], h.data[grandparent]) {
+			return
+		}
+		h.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+// down moves the element at index i towards the leaves until the heap property holds.
+func (h *MinMaxHeap[T]) down(i int) {
+	n := len(h.data)
+	for {
+		// Find the first of the children and grandchildren on the level order of i.
+		first := i
+		for _, j := range [...]int{2*i + 1, 2*i + 2, 4*i + 3, 4*i + 4, 4*i + 5, 4*i + 6} {
+			if j < n && h.before(i, h.data[j], h.data[first]) {
+				first = j
+			}
+		}
+		if first == i {
+			return
+		}
+		h.swap(i, first)
+		if first <= 2*i+2 {
+			return
+		}
+		if parent := (first - 1) / 2; h.before(parent, h.data[first], h.data[parent]) {
+			h.swap(first, parent)
+		}
+		i = first
+	}
+}
+
+// maxIndex returns the index of the largest element of a non-empty heap.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch {
+	case len(h.data) == 1:
+		return 0
+	case len(h.data) == 2 || !h.less(h.data[1], h.data[2]):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// removeAt removes and returns the element at index i.
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	item := h.data[i]
+	last := len(h.data) - 1
+	h.data[i] = h.data[last]
+	h.data[last] = zeroValue[T]()
+	h.data = h.data[:last]
+	if i < last {
+		h.down(i)
+	}
+	return item
+}
+
+// pushPopMax adds an element and then removes and returns the largest element.
+func (h *MinMaxHeap[T]) pushPopMax(data T) T {
+	if len(h.data) == 0 {
+		return data
+	}
+	i := h.maxIndex()
+	if !h.less(data, h.data[i]) {
+		return data
+	}
+	top := h.data[i]
+	h.data[i] = data
+	if i > 0 {
+		if h.less(h.data[i], h.data[0]) {
+			h.swap(i, 0)
+		}
+		h.down(i)
+	}
+	return top
+}
+
+// add adds an element and returns the element evicted from a full bounded heap.
+func (h *MinMaxHeap[T]) add(data T) (T, bool) {
+	if h.capacity > 0 && len(h.data) == h.capacity {
+		return h.pushPopMax(data), true
+	}
+	h.data = append(h.data, data)
+	h.up(len(h.data) - 1)
+	return zeroValue[T](), false
+}
+
+// Push adds an element to the heap in a concurrency-safe manner.
+// A full bounded heap evicts its largest element, which may be data itself.
+func (h *MinMaxHeap[T]) Push(data T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.add(data)
+}
+
+// Add adds an element to the heap in a concurrency-safe manner. If a bounded heap is full,
+// it evicts and returns its largest element, which may be data itself, and true.
+func (h *MinMaxHeap[T]) Add(data T) (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.add(data)
+}
+
+// Pop removes and returns the smallest element in a concurrency-safe manner.
+// It is the same as PopMin.
+func (h *MinMaxHeap[T]) Pop() (T, bool) {
+	return h.PopMin()
+}
+
+// PopMin removes and returns the smallest element in a concurrency-safe manner.
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.data) == 0 {
+		return zeroValue[T](), false
+	}
+	return h.removeAt(0), true
+}
+
+// PopMax removes and returns the largest element in a concurrency-safe manner.
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.data) == 0 {
+		return zeroValue[T](), false
+	}
+	return h.removeAt(h.maxIndex()), true
+}
+
+// Peek returns the smallest element without removing it in a concurrency-safe manner.
+// It is the same as PeekMin.
+func (h *MinMaxHeap[T]) Peek() (T, bool) {
+	return h.PeekMin()
+}
+
+// PeekMin returns the smallest element without removing it in a concurrency-safe manner.
+func (h *MinMaxHeap[T]) PeekMin() (T, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.data) == 0 {
+		return zeroValue[T](), false
+	}
+	return h.data[0], true
+}
+
+// PeekMax returns the largest element without removing it in a concurrency-safe manner.
+func (h *MinMaxHeap[T]) PeekMax() (T, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.data) == 0 {
+		return zeroValue[T](), false
+	}
+	return h.data[h.maxIndex()], true
+}
+
+// PushPop adds an element and then removes and returns the smallest element in a
+// concurrency-safe manner. It is more efficient than Push followed by PopMin and never
+// evicts from a bounded heap.
+func (h *MinMaxHeap[T]) PushPop(data T) T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.data) == 0 || !h.less(h.data[0], data) {
+		return data
+	}
+	top := h.data[0]
+	h.data[0] = data
+	h.down(0)
+	return top
+}
+
+// PushPopMax adds an element and then removes and returns the largest element in a
+// concurrency-safe manner. It is more efficient than Push followed by PopMax.
+func (h *MinMaxHeap[T]) PushPopMax(data T) T {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.pushPopMax(data)
+}
+
+// Capacity returns the maximum number of elements of a bounded heap, or 0 if the heap is
+// unbounded.
+func (h *MinMaxHeap[T]) Capacity() int {
+	return h.capacity
+}
+
+// IsFull returns true if a bounded heap holds as many elements as its capacity in a
+// concurrency-safe manner.
+func (h *MinMaxHeap[T]) IsFull() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.capacity > 0 && len(h.data) == h.capacity
+}
+
+// IsEmpty returns true if the heap is empty in a concurrency-safe manner.
+func (h *MinMaxHeap[T]) IsEmpty() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.data) == 0
+}
+
+// Length returns the number of elements in the heap in a concurrency-safe manner.
+func (h *MinMaxHeap[T]) Length() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.data)
+}
@@ -0,0 +1,155 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/mmygods/gods/ds/collections"
+)
+
+var _ collections.PriorityQueue[int] = (*MinMaxHeap[int])(nil)
+
+// checkMinMax verifies that every element is ordered against its descendants by its level.
+func checkMinMax[T any](t *testing.T, h *MinMaxHeap[T]) {
+	t.Helper()
+	for i := 1; i < len(h.data); i++ {
+		for ancestor := (i - 1) / 2; ; ancestor = (ancestor - 1) / 2 {
+			if h.before(ancestor, h.data[i], h.data[ancestor]) {
+				t.Fatalf("Heap property violated between index %d and ancestor %d", i, ancestor)
+			}
+			if ancestor == 0 {
+				break
+			}
+		}
+	}
+	if h.capacity > 0 && len(h.data) > h.capacity {
+		t.Fatalf("Expected at most %d elements, got %d", h.capacity, len(h.data))
+	}
+}
+
+func TestMinMaxHeap(t *testing.T) {
+	h := NewMinMax[int]()
+	if _, ok := h.PeekMax(); ok {
+		t.Error("Expected PeekMax on an empty heap to fail")
+	}
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		h.Push(v)
+	}
+	if min, _ := h.PeekMin(); min != 1 {
+		t.Errorf("Expected min 1, got %d", min)
+	}
+	if max, _ := h.PeekMax(); max != 9 {
+		t.Errorf("Expected max 9, got %d", max)
+	}
+	if v := h.PushPop(0); v != 0 {
+		t.Errorf("Expected PushPop of a new minimum to return it, got %d", v)
+	}
+	if v := h.PushPop(4); v != 1 || h.Length() != 5 {
+		t.Errorf("Expected PushPop to return 1, got %d", v)
+	}
+	if v := h.PushPopMax(8); v != 9 {
+		t.Errorf("Expected PushPopMax to return 9, got %d", v)
+	}
+	expected := []int{8, 7, 5, 4, 3}
+	for _, e := range expected {
+		if v, ok := h.PopMax(); !ok || v != e {
+			t.Errorf("Expected %d, got %d", e, v)
+		}
+	}
+	if _, ok := h.PopMin(); ok || !h.IsEmpty() {
+		t.Error("Expected heap to be empty")
+	}
+}
+
+func TestMinMaxHeapRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	h := NewMinMaxWithLess(func(a, b float64) bool { return a > b })
+	var reference []float64
+	for n := 0; n < 5000; n++ {
+		data := rng.Float64()
+		switch rng.Intn(6) {
+		case 0, 1:
+			h.Push(data)
+			reference = append(reference, data)
+		case 2:
+			v, ok := h.PopMin()
+			if ok != (len(reference) > 0) {
+				t.Fatal("Unexpected result popping the minimum")
+			}
+			if ok {
+				if e := slices.Max(reference); v != e {
+					t.Fatalf("Expected %g, got %g", e, v)
+				}
+				reference = slices.Delete(reference, slices.Index(reference, v), slices.Index(reference, v)+1)
+			}
+		case 3:
+			v, ok := h.PopMax()
+			if ok != (len(reference) > 0) {
+				t.Fatal("Unexpected result popping the maximum")
+			}
+			if ok {
+				if e := slices.Min(reference); v != e {
+					t.Fatalf("Expected %g, got %g", e, v)
+				}
+				reference = slices.Delete(reference, slices.Index(reference, v), slices.Index(reference, v)+1)
+			}
+		case 4:
+			reference = append(reference, data)
+			v := h.PushPop(data)
+			if e := slices.Max(reference); v != e {
+				t.Fatalf("Expected %g, got %g", e, v)
+			}
+			reference = slices.Delete(reference, slices.Index(reference, v), slices.Index(reference, v)+1)
+		case 5:
+			reference = append(reference, data)
+			v := h.PushPopMax(data)
+			if e := slices.Min(reference); v != e {
+				t.Fatalf("Expected %g, got %g", e, v)
+			}
+			reference = slices.Delete(reference, slices.Index(reference, v), slices.Index(reference, v)+1)
+		}
+		if h.Length() != len(reference) {
+			t.Fatalf("Expected length %d, got %d", len(reference), h.Length())
+		}
+		if n%100 == 0 {
+			checkMinMax(t, h)
+		}
+	}
+	checkMinMax(t, h)
+}
+
+func TestBoundedMinMaxHeap(t *testing.T) {
+	// Keep the five largest values by ordering the heap from the largest.
+	h := NewBoundedMinMaxWithLess(5, func(a, b int) bool { return a > b })
+	rng := rand.New(rand.NewSource(1))
+	var values []int
+	for n := 0; n < 1000; n++ {
+		data := rng.Intn(10000)
+		values = append(values, data)
+		evicted, ok := h.Add(data)
+		if ok != (n >= 5) {
+			t.Fatalf("Expected eviction only when full, got %v after %d values", ok, n)
+		}
+		if ok && !slices.Contains(values, evicted) {
+			t.Fatalf("Evicted %d was never added", evicted)
+		}
+		checkMinMax(t, h)
+	}
+	if !h.IsFull() || h.Capacity() != 5 {
+		t.Fatalf("Expected a full heap of capacity 5, got %d elements", h.Length())
+	}
+	slices.SortFunc(values, func(a, b int) int { return b - a })
+	for _, e := range values[:5] {
+		if v, _ := h.Pop(); v != e {
+			t.Errorf("Expected %d, got %d", e, v)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a zero capacity")
+		}
+	}()
+	NewBoundedMinMax[int](0)
+}
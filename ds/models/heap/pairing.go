@@ -0,0 +1,208 @@
+package heap
+
+import (
+	"cmp"
+	"sync"
+)
+
+// PairingNode is a handle to an element of a PairingHeap, used to change or delete it.
+type PairingNode[T any] struct {
+	value T
+	// child is the leftmost child. prev is the left sibling, or the parent of a leftmost child.
+	child, sibling, prev *PairingNode[T]
+	queued               bool
+}
+
+// Value returns the element held by the node.
+func (n *PairingNode[T]) Value() T {
+	return n.value
+}
+
+// The PairingHeap struct represents a pairing heap ordered by a less function.
+// Push and Meld take O(1) time, DecreaseKey o(log n) amortized, and Pop and Delete
+// O(log n) amortized.
+type PairingHeap[T any] struct {
+	root   *PairingNode[T]
+	length int
+	less   func(a, b T) bool
+	pairs  []*PairingNode[T]
+	mu     sync.RWMutex
+}
+
+// NewPairing creates a new pairing min-heap of naturally ordered elements.
+func NewPairing[T cmp.Ordered]() *PairingHeap[T] {
+	return NewPairingWithLess(cmp.Less[T])
+}
+
+// NewPairingWithLess creates a new pairing heap ordered by less.
+func NewPairingWithLess[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less}
+}
+
+// link makes the tree with the lower root the parent of the other and returns it.
+func (h *PairingHeap[T]) link(a, b *PairingNode[T]) *PairingNode[T] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case h.less(b.value, a.value):
+		a, b = b, a
+	}
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	b.prev = a
+	a.child = b
+	return a
+}
+
+// detach removes the subtree of n from its parent.
+func detach[T any](n *PairingNode[T]) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev, n.sibling = nil, nil
+}
+
+// mergePairs links the siblings starting at first in pairs from left to right, then links
+// the pairs from right to left, and returns the resulting tree.
+func (h *PairingHeap[T]) mergePairs(first *PairingNode[T]) *PairingNode[T] {
+	pairs := h.pairs[:0]
+	for first != nil {
+		a, b := first, first.sibling
+		first = nil
+		if b != nil {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+		pairs = append(pairs, h.link(a, b))
+	}
+	var root *PairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	clear(pairs)
+	h.pairs = pairs
+	return root
+}
+
+// insert adds the element and returns its node.
+func (h *PairingHeap[T]) insert(data T) *PairingNode[T] {
+	n := &PairingNode[T]{value: data, queued: true}
+	h.root = h.link(h.root, n)
+	h.length++
+	return n
+}
+
+// remove takes the node out of the heap.
+func (h *PairingHeap[T]) remove(n *PairingNode[T]) {
+	if n == h.root {
+		h.root = h.mergePairs(n.child)
+	} else {
+		detach(n)
+		h.root = h.link(h.root, h.mergePairs(n.child))
+	}
+	n.child = nil
+	n.queued = false
+	h.length--
+}
+
+// Push adds an element to the heap in a concurrency-safe manner.
+func (h *PairingHeap[T]) Push(data T) {
+	h.Insert(data)
+}
+
+// Insert adds an element to the heap in a concurrency-safe manner and returns its node.
+func (h *PairingHeap[T]) Insert(data T) *PairingNode[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.insert(data)
+}
+
+// Pop removes and returns the top element of the heap in a concurrency-safe manner.
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.root == nil {
+		return zeroValue[T](), false
+	}
+	top := h.root
+	h.remove(top)
+	return top.value, true
+}
+
+// Peek returns the top element of the heap without removing it in a concurrency-safe manner.
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.root == nil {
+		return zeroValue[T](), false
+	}
+	return h.root.value, true
+}
+
+// DecreaseKey replaces the element of a queued node with one that is not lower in priority
+// in a concurrency-safe manner. It returns false if the node was removed or the new
+// element would move it away from the top. The node must belong to h or to a heap melded into it.
+func (h *PairingHeap[T]) DecreaseKey(n *PairingNode[T], data T) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !n.queued || h.less(n.value, data) {
+		return false
+	}
+	n.value = data
+	if n != h.root {
+		detach(n)
+		h.root = h.link(h.root, n)
+	}
+	return true
+}
+
+// Delete removes a queued node from the heap in a concurrency-safe manner.
+// It returns false if the node was already removed. The node must belong to h or to a
+// heap melded into it.
+func (h *PairingHeap[T]) Delete(n *PairingNode[T]) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !n.queued {
+		return false
+	}
+	h.remove(n)
+	return true
+}
+
+// Meld moves every element of other into h in O(1) in a concurrency-safe manner, leaving
+// other empty. Nodes of other stay valid as nodes of h. Both heaps must use the same order.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	other.mu.Lock()
+	root, length := other.root, other.length
+	other.root, other.length = nil, 0
+	other.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.root = h.link(h.root, root)
+	h.length += length
+}
+
+// IsEmpty returns true if the heap is empty in a concurrency-safe manner.
+func (h *PairingHeap[T]) IsEmpty() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.root == nil
+}
+
+// Length returns the number of elements in the heap in a concurrency-safe manner.
+func (h *PairingHeap[T]) Length() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.length
+}
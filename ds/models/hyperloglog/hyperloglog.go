@@ -0,0 +1,293 @@
+// Description: This package contains the implementation of a HyperLogLog cardinality estimator.
+package hyperloglog
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"sort"
+	"sync"
+
+	"github.com/mmygods/gods/ds/hashing"
+)
+
+const (
+	// MinPrecision is the smallest supported precision.
+	MinPrecision = 4
+	// MaxPrecision is the largest supported precision.
+	MaxPrecision = 18
+)
+
+var (
+	// ErrIncompatible is returned when merging estimators of different precisions.
+	ErrIncompatible = errors.New("hyperloglog: estimators are not compatible")
+	// ErrInvalidData is returned when unmarshaling data that does not hold an estimator.
+	ErrInvalidData = errors.New("hyperloglog: invalid data")
+)
+
+// magic identifies the binary format of an estimator.
+var magic = [4]byte{'G', 'H', 'L', '1'}
+
+const (
+	formatSparse byte = iota
+	formatDense
+)
+
+// The HyperLogLog struct represents a cardinality estimator over keys of type T using 2^p
+// registers. With few distinct keys only the non-zero registers are stored; once they would
+// take more space than the full register array the estimator switches to it.
+type HyperLogLog[T any] struct {
+	p         uint8
+	sparse    map[uint32]uint8
+	registers []uint8
+	hash      hashing.Hasher[T]
+	mu        sync.RWMutex
+}
+
+// New creates a new estimator with 2^precision registers. The standard error of the
+// estimate is about 1.04/sqrt(2^precision).
+func New[T any](precision int, hash hashing.Hasher[T]) *HyperLogLog[T] {
+	if precision < MinPrecision || precision > MaxPrecision {
+		panic("hyperloglog: precision must be between 4 and 18")
+	}
+	return &HyperLogLog[T]{
+		p:      uint8(precision),
+		sparse: make(map[uint32]uint8),
+		hash:   hash,
+	}
+}
+
+// Unmarshal creates a new estimator from data produced by MarshalBinary.
+func Unmarshal[T any](data []byte, hash hashing.Hasher[T]) (*HyperLogLog[T], error) {
+	h := &HyperLogLog[T]{hash: hash}
+	if err := h.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// size returns the number of registers.
+func (h *HyperLogLog[T]) size() uint32 {
+	return 1 << h.p
+}
+
+// sparseLimit returns the number of sparse entries above which the dense form is smaller.
+func (h *HyperLogLog[T]) sparseLimit() int {
+	return int(h.size()) / 4
+}
+
+// set raises register index to rho. It returns true if the register changed.
+func (h *HyperLogLog[T]) set(index uint32, rho uint8) bool {
+	if h.registers != nil {
+		if rho <= h.registers[index] {
+			return false
+		}
+		h.registers[index] = rho
+		return true
+	}
+	if rho <= h.sparse[index] {
+		return false
+	}
+	h.sparse[index] = rho
+	if len(h.sparse) > h.sparseLimit() {
+		h.toDense()
+	}
+	return true
+}
+
+// toDense converts the sparse entries into a full register array.
+func (h *HyperLogLog[T]) toDense() {
+	h.registers = make([]uint8, h.size())
+	for index, rho := range h.sparse {
+		h.registers[index] = rho
+	}
+	h.sparse = nil
+}
+
+// add records the key.
+func (h *HyperLogLog[T]) add(key T) bool {
+	x := h.hash(key)
+	index := uint32(x >> (64 - h.p))
+	rho := uint8(bits.LeadingZeros64(x<<h.p|1<<(h.p-1))) + 1
+	return h.set(index, rho)
+}
+
+// count returns the estimated number of distinct keys.
+func (h *HyperLogLog[T]) count() uint64 {
+	m := float64(h.size())
+	sum := 0.0
+	zeros := 0
+	if h.registers != nil {
+		for _, rho := range h.registers {
+			sum += math.Ldexp(1, -int(rho))
+			if rho == 0 {
+				zeros++
+			}
+		}
+	} else {
+		zeros = int(h.size()) - len(h.sparse)
+		sum = float64(zeros)
+		for _, rho := range h.sparse {
+			sum += math.Ldexp(1, -int(rho))
+		}
+	}
+	estimate := alpha(m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// alpha returns the bias correction constant for m registers.
+func alpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/m)
+}
+
+// Add records the key in a concurrency-safe manner.
+// It returns true if the estimate may have changed.
+func (h *HyperLogLog[T]) Add(key T) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.add(key)
+}
+
+// Count returns the estimated number of distinct keys in a concurrency-safe manner.
+func (h *HyperLogLog[T]) Count() uint64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.count()
+}
+
+// Precision returns the precision of the estimator.
+func (h *HyperLogLog[T]) Precision() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return int(h.p)
+}
+
+// IsSparse returns true if the estimator stores only its non-zero registers.
+func (h *HyperLogLog[T]) IsSparse() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.registers == nil
+}
+
+// Merge adds the keys recorded by other to h in a concurrency-safe manner.
+// Both estimators must have the same precision and use the same Hasher.
+func (h *HyperLogLog[T]) Merge(other *HyperLogLog[T]) error {
+	other.mu.RLock()
+	p := other.p
+	var registers []uint8
+	var sparse map[uint32]uint8
+	if other.registers != nil {
+		registers = append([]uint8(nil), other.registers...)
+	} else {
+		sparse = make(map[uint32]uint8, len(other.sparse))
+		for index, rho := range other.sparse {
+			sparse[index] = rho
+		}
+	}
+	other.mu.RUnlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.p != p {
+		return ErrIncompatible
+	}
+	for index, rho := range registers {
+		if rho > 0 {
+			h.set(uint32(index), rho)
+		}
+	}
+	for index, rho := range sparse {
+		h.set(index, rho)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the estimator in a concurrency-safe manner. The hash function is not
+// encoded; the estimator must be unmarshaled with the same Hasher.
+//
+// The format is the magic "GHL1", the precision, a form byte and then either the number of
+// sparse entries followed by each entry as a little-endian uint32 holding index<<8|rho in
+// ascending order, or the 2^p registers as one byte each.
+func (h *HyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	data := append(magic[:len(magic):len(magic)], h.p)
+	if h.registers != nil {
+		data = append(data, formatDense)
+		return append(data, h.registers...), nil
+	}
+	entries := make([]uint32, 0, len(h.sparse))
+	for index, rho := range h.sparse {
+		entries = append(entries, index<<8|uint32(rho))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i] < entries[j] })
+	data = append(data, formatSparse)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(entries)))
+	for _, entry := range entries {
+		data = binary.LittleEndian.AppendUint32(data, entry)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the estimator with data produced by MarshalBinary,
+// in a concurrency-safe manner. The hash function of the estimator is kept.
+func (h *HyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 6 || [4]byte(data[:4]) != magic {
+		return ErrInvalidData
+	}
+	p := data[4]
+	if p < MinPrecision || p > MaxPrecision {
+		return ErrInvalidData
+	}
+	size := uint32(1) << p
+	maxRho := 64 - p + 1
+	var registers []uint8
+	var sparse map[uint32]uint8
+	switch data[5] {
+	case formatDense:
+		if uint32(len(data)-6) != size {
+			return ErrInvalidData
+		}
+		registers = append([]uint8(nil), data[6:]...)
+		for _, rho := range registers {
+			if rho > maxRho {
+				return ErrInvalidData
+			}
+		}
+	case formatSparse:
+		if len(data) < 10 {
+			return ErrInvalidData
+		}
+		n := binary.LittleEndian.Uint32(data[6:])
+		if n > size || uint64(len(data)-10) != 4*uint64(n) {
+			return ErrInvalidData
+		}
+		sparse = make(map[uint32]uint8, n)
+		for i := uint32(0); i < n; i++ {
+			entry := binary.LittleEndian.Uint32(data[10+4*i:])
+			index, rho := entry>>8, uint8(entry)
+			if index >= size || rho == 0 || rho > maxRho {
+				return ErrInvalidData
+			}
+			sparse[index] = rho
+		}
+	default:
+		return ErrInvalidData
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.p, h.registers, h.sparse = p, registers, sparse
+	return nil
+}
@@ -0,0 +1,155 @@
+package hyperloglog_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/mmygods/gods/ds/hashing"
+	"github.com/mmygods/gods/ds/models/hyperloglog"
+)
+
+// relativeError returns the relative error of an estimate of n.
+func relativeError(estimate uint64, n int) float64 {
+	return math.Abs(float64(estimate)-float64(n)) / float64(n)
+}
+
+func TestHyperLogLogRelativeError(t *testing.T) {
+	tests := []struct {
+		precision int
+		n         int
+	}{
+		{precision: 10, n: 100},
+		{precision: 10, n: 10000},
+		{precision: 10, n: 200000},
+		{precision: 14, n: 1000},
+		{precision: 14, n: 50000},
+		{precision: 14, n: 1000000},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("p=%d n=%d", test.precision, test.n), func(t *testing.T) {
+			h := hyperloglog.New(test.precision, hashing.Int)
+			for i := 0; i < test.n; i++ {
+				h.Add(i)
+				// Duplicates must not change the estimate.
+				h.Add(i / 2)
+			}
+			// Allow four standard errors.
+			bound := 4 * 1.04 / math.Sqrt(float64(int(1)<<test.precision))
+			if err := relativeError(h.Count(), test.n); err > bound {
+				t.Errorf("Expected relative error below %.4f, got %.4f (estimate %d)", bound, err, h.Count())
+			}
+		})
+	}
+}
+
+func TestHyperLogLogSparse(t *testing.T) {
+	h := hyperloglog.New(14, hashing.String)
+	if h.Count() != 0 {
+		t.Errorf("Expected an empty estimator to count 0, got %d", h.Count())
+	}
+	for i := 0; i < 100; i++ {
+		h.Add(fmt.Sprint("user-", i))
+	}
+	if !h.IsSparse() {
+		t.Error("Expected few keys to use the sparse representation")
+	}
+	if estimate := h.Count(); estimate < 98 || estimate > 102 {
+		t.Errorf("Expected an estimate near 100, got %d", estimate)
+	}
+	for i := 100; i < 10000; i++ {
+		h.Add(fmt.Sprint("user-", i))
+	}
+	if h.IsSparse() {
+		t.Error("Expected many keys to use the dense representation")
+	}
+	if err := relativeError(h.Count(), 10000); err > 0.04 {
+		t.Errorf("Expected relative error below 0.04, got %.4f", err)
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	shards := make([]*hyperloglog.HyperLogLog[int], 4)
+	for s := range shards {
+		shards[s] = hyperloglog.New(12, hashing.Int)
+	}
+	// Users are spread over the shards with overlap; 60000 are distinct.
+	for i := 0; i < 80000; i++ {
+		shards[i%4].Add(i % 60000)
+	}
+	// A small shard stays sparse and merges into dense ones.
+	small := hyperloglog.New(12, hashing.Int)
+	for i := 0; i < 50; i++ {
+		small.Add(i)
+	}
+	merged := hyperloglog.New(12, hashing.Int)
+	for _, shard := range append(shards, small) {
+		if err := merged.Merge(shard); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+	if err := relativeError(merged.Count(), 60000); err > 4*1.04/64 {
+		t.Errorf("Expected merged estimate near 60000, got %d", merged.Count())
+	}
+
+	union := hyperloglog.New(12, hashing.Int)
+	for i := 0; i < 60000; i++ {
+		union.Add(i)
+	}
+	if merged.Count() != union.Count() {
+		t.Errorf("Expected merge to equal the estimator of the union, got %d and %d", merged.Count(), union.Count())
+	}
+	if err := merged.Merge(hyperloglog.New(10, hashing.Int)); !errors.Is(err, hyperloglog.ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestHyperLogLogMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{name: "Sparse", n: 20},
+		{name: "Dense", n: 5000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := hyperloglog.New(11, hashing.Int)
+			for i := 0; i < test.n; i++ {
+				h.Add(i)
+			}
+			data, err := h.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			loaded, err := hyperloglog.Unmarshal(data, hashing.Int)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if loaded.Count() != h.Count() || loaded.Precision() != 11 || loaded.IsSparse() != h.IsSparse() {
+				t.Error("Expected the estimator to survive a round trip")
+			}
+			again, _ := loaded.MarshalBinary()
+			if !bytes.Equal(again, data) {
+				t.Error("Expected the encoding to be stable")
+			}
+			if _, err := hyperloglog.Unmarshal(data[:len(data)-1], hashing.Int); !errors.Is(err, hyperloglog.ErrInvalidData) {
+				t.Errorf("Expected ErrInvalidData for truncated data, got %v", err)
+			}
+		})
+	}
+
+	h := hyperloglog.New(4, hashing.Int)
+	h.Add(42)
+	data, _ := h.MarshalBinary()
+	// Int(42) = 0xa759ea27d4727622 lands in register 10 with rho 2.
+	expected := []byte{'G', 'H', 'L', '1', 4, 0, 1, 0, 0, 0, 2, 10, 0, 0}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Expected encoding %v, got %v", expected, data)
+	}
+	if _, err := hyperloglog.Unmarshal([]byte("junk"), hashing.Int); !errors.Is(err, hyperloglog.ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for junk, got %v", err)
+	}
+}
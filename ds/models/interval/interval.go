@@ -0,0 +1,369 @@
+// Description: This package contains the implementation of an augmented interval tree.
+package interval
+
+import (
+	"cmp"
+	"sync"
+)
+
+// Bounds selects whether the upper endpoint of an interval belongs to it.
+type Bounds int
+
+const (
+	// Closed intervals [Low, High] contain both endpoints.
+	Closed Bounds = iota
+	// HalfOpen intervals [Low, High) contain Low but not High.
+	HalfOpen
+)
+
+// Interval represents a range of ordered values between Low and High.
+type Interval[T cmp.Ordered] struct {
+	Low  T
+	High T
+}
+
+// Entry represents an interval and the value stored with it.
+type Entry[T cmp.Ordered, V any] struct {
+	Interval Interval[T]
+	Value    V
+}
+
+// intervalNode represents a node in the tree, augmented with the largest
+// upper endpoint in its subtree.
+type intervalNode[T cmp.Ordered, V any] struct {
+	entry  Entry[T, V]
+	max    T
+	height int
+	left   *intervalNode[T, V]
+	right  *intervalNode[T, V]
+}
+
+// The Tree struct represents an AVL-balanced interval tree ordered by (Low, High).
+// Each distinct interval is stored once.
+type Tree[T cmp.Ordered, V any] struct {
+	bounds Bounds
+	root   *intervalNode[T, V]
+	length int
+	mu     sync.RWMutex
+}
+
+func zeroValue[T any]() T {
+	var zero T
+	return zero
+}
+
+// New creates a new interval tree interpreting intervals with the given bounds.
+func New[T cmp.Ordered, V any](bounds Bounds) *Tree[T, V] {
+	return &Tree[T, V]{bounds: bounds}
+}
+
+// compareIntervals orders intervals by their lower and then their upper endpoint.
+func compareIntervals[T cmp.Ordered](a, b Interval[T]) int {
+	if c := cmp.Compare(a.Low, b.Low); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.High, b.High)
+}
+
+// before reports whether an interval ending at high lies entirely before one starting at low.
+func (t *Tree[T, V]) before(high, low T) bool {
+	if t.bounds == HalfOpen {
+		return high <= low
+	}
+	return high < low
+}
+
+// empty reports whether an interval contains no point, which for a half-open tree is also
+// the case when Low equals High.
+func (t *Tree[T, V]) empty(interval Interval[T]) bool {
+	if t.bounds == HalfOpen {
+		return interval.Low >= interval.High
+	}
+	return interval.Low > interval.High
+}
+
+// overlaps reports whether a and b share at least one point.
+func (t *Tree[T, V]) overlaps(a, b Interval[T]) bool {
+	return !t.before(a.High, b.Low) && !t.before(b.High, a.Low)
+}
+
+func height[T cmp.Ordered, V any](n *intervalNode[T, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// update recomputes the height and the augmented maximum of n from its children.
+func (n *intervalNode[T, V]) update() {
+	n.height = 1 + max(height(n.left), height(n.right))
+	n.max = n.entry.Interval.High
+	if n.left != nil && n.left.max > n.max {
+		n.max = n.left.max
+	}
+	if n.right != nil && n.right.max > n.max {
+		n.max = n.right.max
+	}
+}
+
+func rotateLeft[T cmp.Ordered, V any](n *intervalNode[T, V]) *intervalNode[T, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func rotateRight[T cmp.Ordered, V any](n *intervalNode[T, V]) *intervalNode[T, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// balance restores the AVL invariant at n and returns the new subtree root.
+func balance[T cmp.Ordered, V any](n *intervalNode[T, V]) *intervalNode[T, V] {
+	n.update()
+	switch diff := height(n.left) - height(n.right); {
+	case diff > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case diff < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// insert adds or replaces the entry below n. added reports whether the interval was new.
+func insert[T cmp.Ordered, V any](n *intervalNode[T, V], entry Entry[T, V], added *bool) *intervalNode[T, V] {
+	if n == nil {
+		*added = true
+		node := &intervalNode[T, V]{entry: entry}
+		node.update()
+		return node
+	}
+	switch c := compareIntervals(entry.Interval, n.entry.Interval); {
+	case c < 0:
+		n.left = insert(n.left, entry, added)
+	case c > 0:
+		n.right = insert(n.right, entry, added)
+	default:
+		n.entry.Value = entry.Value
+		return n
+	}
+	return balance(n)
+}
+
+// removeMin removes the smallest node below n, returning the new subtree root and the removed node.
+func removeMin[T cmp.Ordered, V any](n *intervalNode[T, V]) (*intervalNode[T, V], *intervalNode[T, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var removed *intervalNode[T, V]
+	n.left, removed = removeMin(n.left)
+	return balance(n), removed
+}
+
+// remove removes the interval below n. removed reports whether it was present.
+func remove[T cmp.Ordered, V any](n *intervalNode[T, V], interval Interval[T], removed *bool) *intervalNode[T, V] {
+	if n == nil {
+		return nil
+	}
+	switch c := compareIntervals(interval, n.entry.Interval); {
+	case c < 0:
+		n.left = remove(n.left, interval, removed)
+	case c > 0:
+		n.right = remove(n.right, interval, removed)
+	default:
+		*removed = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		right, successor := removeMin(n.right)
+		successor.left = n.left
+		successor.right = right
+		return balance(successor)
+	}
+	return balance(n)
+}
+
+// search calls fn, in order, for every entry below n overlapping query.
+// It returns false if fn stopped the search.
+func (t *Tree[T, V]) search(n *intervalNode[T, V], query Interval[T], fn func(Entry[T, V]) bool) bool {
+	if n == nil || t.before(n.max, query.Low) {
+		return true
+	}
+	if !t.search(n.left, query, fn) {
+		return false
+	}
+	if t.before(query.High, n.entry.Interval.Low) {
+		return true
+	}
+	if t.overlaps(n.entry.Interval, query) && !fn(n.entry) {
+		return false
+	}
+	return t.search(n.right, query, fn)
+}
+
+// get returns the value stored with the interval.
+func (t *Tree[T, V]) get(interval Interval[T]) (V, bool) {
+	n := t.root
+	for n != nil {
+		switch c := compareIntervals(interval, n.entry.Interval); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.entry.Value, true
+		}
+	}
+	return zeroValue[V](), false
+}
+
+// Insert stores the value with the interval in a concurrency-safe manner, replacing
+// the value of an identical interval. It returns false if the interval is empty, that is if
+// Low is greater than High or, for a half-open tree, equal to it.
+func (t *Tree[T, V]) Insert(interval Interval[T], value V) bool {
+	if t.empty(interval) {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	added := false
+	t.root = insert(t.root, Entry[T, V]{Interval: interval, Value: value}, &added)
+	if added {
+		t.length++
+	}
+	return true
+}
+
+// Delete removes the interval in a concurrency-safe manner.
+func (t *Tree[T, V]) Delete(interval Interval[T]) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	removed := false
+	t.root = remove(t.root, interval, &removed)
+	if removed {
+		t.length--
+	}
+	return removed
+}
+
+// Get returns the value stored with the interval in a concurrency-safe manner.
+func (t *Tree[T, V]) Get(interval Interval[T]) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.get(interval)
+}
+
+// Length returns the number of intervals in the tree in a concurrency-safe manner.
+func (t *Tree[T, V]) Length() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.length
+}
+
+// IsEmpty returns true if the tree holds no intervals in a concurrency-safe manner.
+func (t *Tree[T, V]) IsEmpty() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.length == 0
+}
+
+// query sends the entries overlapping the interval to a channel while holding the read lock.
+func (t *Tree[T, V]) query(interval Interval[T]) <-chan Entry[T, V] {
+	t.mu.RLock()
+	ch := make(chan Entry[T, V])
+	go func() {
+		defer t.mu.RUnlock()
+		t.search(t.root, interval, func(entry Entry[T, V]) bool {
+			ch <- entry
+			return true
+		})
+		close(ch)
+	}()
+	return ch
+}
+
+// Overlaps returns a channel that iterates over the entries whose intervals share a point
+// with [low, high], ordered by interval, in a concurrency-safe manner. The query follows
+// the bounds of the tree, so for a half-open tree it is [low, high), and an empty query
+// matches no entry.
+func (t *Tree[T, V]) Overlaps(low, high T) <-chan Entry[T, V] {
+	query := Interval[T]{Low: low, High: high}
+	if t.empty(query) {
+		ch := make(chan Entry[T, V])
+		close(ch)
+		return ch
+	}
+	return t.query(query)
+}
+
+// Stabbing returns a channel that iterates over the entries whose intervals contain the point,
+// ordered by interval, in a concurrency-safe manner.
+func (t *Tree[T, V]) Stabbing(point T) <-chan Entry[T, V] {
+	if t.bounds == HalfOpen {
+		// The half-open query [point, point) is empty, so it cannot be answered by Overlaps.
+		return t.stabHalfOpen(point)
+	}
+	return t.query(Interval[T]{Low: point, High: point})
+}
+
+// stabHalfOpen sends the entries of a half-open tree with low <= point < high to a channel.
+func (t *Tree[T, V]) stabHalfOpen(point T) <-chan Entry[T, V] {
+	t.mu.RLock()
+	ch := make(chan Entry[T, V])
+	go func() {
+		defer t.mu.RUnlock()
+		var walk func(n *intervalNode[T, V])
+		walk = func(n *intervalNode[T, V]) {
+			if n == nil || n.max <= point {
+				return
+			}
+			walk(n.left)
+			if n.entry.Interval.Low > point {
+				return
+			}
+			if point < n.entry.Interval.High {
+				ch <- n.entry
+			}
+			walk(n.right)
+		}
+		walk(t.root)
+		close(ch)
+	}()
+	return ch
+}
+
+// Range returns a channel that iterates over all entries ordered by interval in a concurrency-safe manner.
+func (t *Tree[T, V]) Range() <-chan Entry[T, V] {
+	t.mu.RLock()
+	ch := make(chan Entry[T, V])
+	go func() {
+		defer t.mu.RUnlock()
+		var walk func(n *intervalNode[T, V])
+		walk = func(n *intervalNode[T, V]) {
+			if n == nil {
+				return
+			}
+			walk(n.left)
+			ch <- n.entry
+			walk(n.right)
+		}
+		walk(t.root)
+		close(ch)
+	}()
+	return ch
+}
@@ -0,0 +1,170 @@
+package interval_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/interval"
+)
+
+func collect(ch <-chan interval.Entry[int, string]) []string {
+	var out []string
+	for entry := range ch {
+		out = append(out, entry.Value)
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIntervalQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		bounds   interval.Bounds
+		overlaps [2]int
+		expected []string
+		point    int
+		stabbing []string
+	}{
+		{
+			name:     "Closed",
+			bounds:   interval.Closed,
+			overlaps: [2]int{5, 10},
+			expected: []string{"a", "b", "c"},
+			point:    12,
+			stabbing: []string{"b", "c"},
+		},
+		{
+			name:     "Half-open",
+			bounds:   interval.HalfOpen,
+			overlaps: [2]int{5, 10},
+			expected: []string{"b"},
+			point:    12,
+			stabbing: []string{"c"},
+		},
+		{
+			name:     "Half-open empty query",
+			bounds:   interval.HalfOpen,
+			overlaps: [2]int{10, 10},
+			point:    10,
+			stabbing: []string{"b", "c"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree := interval.New[int, string](test.bounds)
+			tree.Insert(interval.Interval[int]{Low: 0, High: 5}, "a")
+			tree.Insert(interval.Interval[int]{Low: 6, High: 12}, "b")
+			tree.Insert(interval.Interval[int]{Low: 10, High: 15}, "c")
+			tree.Insert(interval.Interval[int]{Low: 20, High: 30}, "d")
+			if got := collect(tree.Overlaps(test.overlaps[0], test.overlaps[1])); !equal(got, test.expected) {
+				t.Errorf("Expected overlaps %v, got %v", test.expected, got)
+			}
+			if got := collect(tree.Stabbing(test.point)); !equal(got, test.stabbing) {
+				t.Errorf("Expected stabbing %v, got %v", test.stabbing, got)
+			}
+		})
+	}
+}
+
+func TestIntervalInsertDelete(t *testing.T) {
+	tree := interval.New[int, string](interval.Closed)
+	if tree.Insert(interval.Interval[int]{Low: 5, High: 1}, "bad") {
+		t.Error("Expected Insert to reject an inverted interval")
+	}
+	halfOpen := interval.New[int, string](interval.HalfOpen)
+	if halfOpen.Insert(interval.Interval[int]{Low: 3, High: 3}, "empty") || !halfOpen.IsEmpty() {
+		t.Error("Expected a half-open tree to reject an empty interval")
+	}
+	if !tree.Insert(interval.Interval[int]{Low: 3, High: 3}, "point") || !tree.Delete(interval.Interval[int]{Low: 3, High: 3}) {
+		t.Error("Expected a closed tree to store a single point")
+	}
+	tree.Insert(interval.Interval[int]{Low: 1, High: 5}, "a")
+	tree.Insert(interval.Interval[int]{Low: 1, High: 5}, "b")
+	if tree.Length() != 1 {
+		t.Errorf("Expected identical intervals to be stored once, got length %d", tree.Length())
+	}
+	if value, _ := tree.Get(interval.Interval[int]{Low: 1, High: 5}); value != "b" {
+		t.Errorf("Expected value to be replaced, got %q", value)
+	}
+	if tree.Delete(interval.Interval[int]{Low: 1, High: 4}) {
+		t.Error("Expected Delete to return false for missing interval")
+	}
+	if !tree.Delete(interval.Interval[int]{Low: 1, High: 5}) || !tree.IsEmpty() {
+		t.Error("Expected Delete to empty the tree")
+	}
+}
+
+func TestIntervalRandomized(t *testing.T) {
+	for _, bounds := range []interval.Bounds{interval.Closed, interval.HalfOpen} {
+		tree := interval.New[int, int](bounds)
+		reference := map[interval.Interval[int]]bool{}
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 3000; i++ {
+			low := r.Intn(1000)
+			iv := interval.Interval[int]{Low: low, High: low + r.Intn(50)}
+			if r.Intn(4) == 0 {
+				if tree.Delete(iv) != reference[iv] {
+					t.Fatalf("Delete(%v) disagreed with reference", iv)
+				}
+				delete(reference, iv)
+			} else {
+				// A half-open interval with Low equal to High is empty and rejected.
+				valid := bounds == interval.Closed || iv.Low < iv.High
+				if tree.Insert(iv, 0) != valid {
+					t.Fatalf("Insert(%v): expected %v", iv, valid)
+				}
+				if valid {
+					reference[iv] = true
+				}
+			}
+		}
+		if tree.Length() != len(reference) {
+			t.Fatalf("Expected length %d, got %d", len(reference), tree.Length())
+		}
+		for q := 0; q < 100; q++ {
+			low := r.Intn(1000)
+			high := low + r.Intn(30)
+			expected := 0
+			for iv := range reference {
+				if bounds == interval.Closed && iv.Low <= high && low <= iv.High {
+					expected++
+				}
+				if bounds == interval.HalfOpen && low < high && iv.Low < high && low < iv.High {
+					expected++
+				}
+			}
+			got := 0
+			for range tree.Overlaps(low, high) {
+				got++
+			}
+			if got != expected {
+				t.Errorf("Overlaps(%d, %d): expected %d entries, got %d", low, high, expected, got)
+			}
+
+			expected = 0
+			for iv := range reference {
+				if iv.Low <= low && (low < iv.High || bounds == interval.Closed && low == iv.High) {
+					expected++
+				}
+			}
+			got = 0
+			for range tree.Stabbing(low) {
+				got++
+			}
+			if got != expected {
+				t.Errorf("Stabbing(%d): expected %d entries, got %d", low, expected, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Description: This package contains the implementation of a multimap.
+package multimap
+
+import (
+	"sync"
+
+	"github.com/mmygods/gods/ds/collections"
+	"github.com/mmygods/gods/ds/models/dll"
+)
+
+// Entry represents a single key-value pair stored in the multimap.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// The MultiMap struct represents a map that associates each key with a list of values.
+type MultiMap[K comparable, V any] struct {
+	data    map[K]collections.List[V]
+	newList func() collections.List[V]
+	equal   func(a, b V) bool
+	values  int
+	mu      sync.RWMutex
+}
+
+// New creates a new multimap storing values in doubly linked lists and comparing them with ==.
+func New[K comparable, V comparable]() *MultiMap[K, V] {
+	return NewWithEqual[K](func(a, b V) bool {
+		return a == b
+	})
+}
+
+// NewWithEqual creates a new multimap storing values in doubly linked lists and comparing
+// them with equal.
+func NewWithEqual[K comparable, V any](equal func(a, b V) bool) *MultiMap[K, V] {
+	return NewWithList[K](func() collections.List[V] {
+		return &dll.DoublyLinkedList[V]{}
+	}, equal)
+}
+
+// NewWithList creates a new multimap storing values in lists created by newList and
+// comparing them with equal.
+func NewWithList[K comparable, V any](newList func() collections.List[V], equal func(a, b V) bool) *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		data:    make(map[K]collections.List[V]),
+		newList: newList,
+		equal:   equal,
+	}
+}
+
+// put adds a value to the list of values associated with the key.
+func (m *MultiMap[K, V]) put(key K, value V) bool {
+	list, ok := m.data[key]
+	if !ok {
+		list = m.newList()
+		m.data[key] = list
+	}
+	if !list.Append(value) {
+		if list.IsEmpty() {
+			delete(m.data, key)
+		}
+		return false
+	}
+	m.values++
+	return true
+}
+
+// get returns a copy of the values associated with the key.
+func (m *MultiMap[K, V]) get(key K) ([]V, bool) {
+	list, ok := m.data[key]
+	if !ok {
+		return nil, false
+	}
+	values := make([]V, 0, list.Length())
+	for value := range list.Range() {
+		values = append(values, value)
+	}
+	return values, true
+}
+
+// contains returns true if the value is associated with the key.
+func (m *MultiMap[K, V]) contains(key K, value V) bool {
+	list, ok := m.data[key]
+	if !ok {
+		return false
+	}
+	return m.indexOf(list, value) >= 0
+}
+
+// remove removes the first occurrence of the value associated with the key.
+func (m *MultiMap[K, V]) remove(key K, value V) bool {
+	list, ok := m.data[key]
+	if !ok {
+		return false
+	}
+	index := m.indexOf(list, value)
+	if index < 0 || !list.Delete(index) {
+		return false
+	}
+	m.values--
+	if list.IsEmpty() {
+		delete(m.data, key)
+	}
+	return true
+}
+
+// removeAll removes all values associated with the key.
+func (m *MultiMap[K, V]) removeAll(key K) bool {
+	list, ok := m.data[key]
+	if !ok {
+		return false
+	}
+	m.values -= list.Length()
+	delete(m.data, key)
+	return true
+}
+
+// indexOf returns the index of the first occurrence of the value in the list, or -1.
+func (m *MultiMap[K, V]) indexOf(list collections.List[V], value V) int {
+	index, i := -1, 0
+	// The channel is drained to release the list.
+	for data := range list.Range() {
+		if index < 0 && m.equal(data, value) {
+			index = i
+		}
+		i++
+	}
+	return index
+}
+
+// Put adds a value to the list of values associated with the key in a concurrency-safe manner.
+func (m *MultiMap[K, V]) Put(key K, value V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.put(key, value)
+}
+
+// Get returns a copy of the values associated with the key in a concurrency-safe manner.
+func (m *MultiMap[K, V]) Get(key K) ([]V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.get(key)
+}
+
+// ContainsKey returns true if at least one value is associated with the key in a concurrency-safe manner.
+func (m *MultiMap[K, V]) ContainsKey(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.data[key]
+	return ok
+}
+
+// Contains returns true if the value is associated with the key in a concurrency-safe manner.
+func (m *MultiMap[K, V]) Contains(key K, value V) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.contains(key, value)
+}
+
+// Remove removes the first occurrence of the value associated with the key in a concurrency-safe manner.
+func (m *MultiMap[K, V]) Remove(key K, value V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.remove(key, value)
+}
+
+// RemoveAll removes all values associated with the key in a concurrency-safe manner.
+func (m *MultiMap[K, V]) RemoveAll(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.removeAll(key)
+}
+
+// KeyCount returns the number of distinct keys in a concurrency-safe manner.
+func (m *MultiMap[K, V]) KeyCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.data)
+}
+
+// ValueCount returns the total number of values across all keys in a concurrency-safe manner.
+func (m *MultiMap[K, V]) ValueCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.values
+}
+
+// IsEmpty returns true if the multimap holds no values in a concurrency-safe manner.
+func (m *MultiMap[K, V]) IsEmpty() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.values == 0
+}
+
+// Keys returns the distinct keys of the multimap in a concurrency-safe manner.
+func (m *MultiMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := make([]K, 0, len(m.data))
+	for key := range m.data {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Range returns a channel that iterates over all key-value pairs in a concurrency-safe manner.
+// Values of the same key are produced in list order; the order of keys is unspecified.
+func (m *MultiMap[K, V]) Range() <-chan Entry[K, V] {
+	m.mu.RLock()
+	ch := make(chan Entry[K, V])
+	go func() {
+		defer m.mu.RUnlock()
+		for key, list := range m.data {
+			for value := range list.Range() {
+				ch <- Entry[K, V]{Key: key, Value: value}
+			}
+		}
+		close(ch)
+	}()
+	return ch
+}
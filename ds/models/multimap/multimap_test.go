@@ -0,0 +1,184 @@
+package multimap_test
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/mmygods/gods/ds/collections"
+	"github.com/mmygods/gods/ds/models/dll"
+	"github.com/mmygods/gods/ds/models/multimap"
+)
+
+func TestMultiMapPutGet(t *testing.T) {
+	m := multimap.New[string, int]()
+	m.Put("a", 1)
+	m.Put("a", 2)
+	m.Put("b", 3)
+	m.Put("a", 1)
+
+	values, ok := m.Get("a")
+	if !ok {
+		t.Fatal("Expected key a to be present")
+	}
+	expected := []int{1, 2, 1}
+	if len(values) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, values)
+	}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, values)
+		}
+	}
+	if _, ok := m.Get("c"); ok {
+		t.Error("Expected key c to be absent")
+	}
+	if m.KeyCount() != 2 {
+		t.Errorf("Expected 2 keys, got %d", m.KeyCount())
+	}
+	if m.ValueCount() != 4 {
+		t.Errorf("Expected 4 values, got %d", m.ValueCount())
+	}
+}
+
+func TestMultiMapRemove(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []int
+		remove     int
+		expected   bool
+		keyCount   int
+		valueCount int
+	}{
+		{
+			name:       "Remove existing value",
+			values:     []int{1, 2},
+			remove:     1,
+			expected:   true,
+			keyCount:   1,
+			valueCount: 1,
+		},
+		{
+			name:       "Remove last value drops key",
+			values:     []int{1},
+			remove:     1,
+			expected:   true,
+			keyCount:   0,
+			valueCount: 0,
+		},
+		{
+			name:       "Remove missing value",
+			values:     []int{1, 2},
+			remove:     3,
+			expected:   false,
+			keyCount:   1,
+			valueCount: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := multimap.New[string, int]()
+			for _, value := range test.values {
+				m.Put("key", value)
+			}
+			if m.Remove("key", test.remove) != test.expected {
+				t.Errorf("Expected Remove to return %t", test.expected)
+			}
+			if m.KeyCount() != test.keyCount {
+				t.Errorf("Expected %d keys, got %d", test.keyCount, m.KeyCount())
+			}
+			if m.ValueCount() != test.valueCount {
+				t.Errorf("Expected %d values, got %d", test.valueCount, m.ValueCount())
+			}
+		})
+	}
+}
+
+func TestMultiMapRemoveAll(t *testing.T) {
+	m := multimap.New[string, int]()
+	m.Put("a", 1)
+	m.Put("a", 2)
+	m.Put("b", 3)
+	if !m.RemoveAll("a") {
+		t.Error("Expected RemoveAll to return true")
+	}
+	if m.RemoveAll("a") {
+		t.Error("Expected RemoveAll to return false for missing key")
+	}
+	if m.ContainsKey("a") || m.KeyCount() != 1 || m.ValueCount() != 1 {
+		t.Errorf("Unexpected state after RemoveAll: %d keys, %d values", m.KeyCount(), m.ValueCount())
+	}
+}
+
+func TestMultiMapWithList(t *testing.T) {
+	created := 0
+	m := multimap.NewWithList[string](func() collections.List[int] {
+		created++
+		return &dll.DoublyLinkedList[int]{}
+	}, func(a, b int) bool {
+		return a == b
+	})
+	m.Put("a", 1)
+	m.Put("a", 2)
+	m.Put("b", 3)
+	if created != 2 {
+		t.Errorf("Expected 2 lists to be created, got %d", created)
+	}
+	if !m.Contains("a", 2) || m.Contains("b", 2) {
+		t.Error("Contains returned an unexpected result")
+	}
+}
+
+func TestMultiMapWithEqual(t *testing.T) {
+	m := multimap.NewWithEqual[string](slices.Equal[[]int])
+	m.Put("a", []int{1, 2})
+	m.Put("a", []int{3})
+	m.Put("a", []int{1, 2})
+	if !m.Contains("a", []int{3}) || m.Contains("a", []int{1}) {
+		t.Error("Contains returned an unexpected result")
+	}
+	if !m.Remove("a", []int{1, 2}) || m.Remove("a", []int{1}) {
+		t.Error("Remove returned an unexpected result")
+	}
+	values, _ := m.Get("a")
+	if len(values) != 2 || !slices.Equal(values[0], []int{3}) || !slices.Equal(values[1], []int{1, 2}) {
+		t.Errorf("Expected the first occurrence to be removed, got %v", values)
+	}
+}
+
+func TestMultiMapRange(t *testing.T) {
+	m := multimap.New[string, int]()
+	m.Put("a", 1)
+	m.Put("a", 2)
+	m.Put("b", 3)
+	var values []int
+	for entry := range m.Range() {
+		if (entry.Key == "a") != (entry.Value < 3) {
+			t.Errorf("Unexpected entry %v", entry)
+		}
+		values = append(values, entry.Value)
+	}
+	sort.Ints(values)
+	if len(values) != 3 || values[0] != 1 || values[1] != 2 || values[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v", values)
+	}
+}
+
+func TestMultiMapConcurrency(t *testing.T) {
+	m := multimap.New[int, int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Put(key%10, j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if m.KeyCount() != 10 || m.ValueCount() != 10000 {
+		t.Errorf("Expected 10 keys and 10000 values, got %d and %d", m.KeyCount(), m.ValueCount())
+	}
+}
@@ -0,0 +1,490 @@
+package roaring
+
+import (
+	"math/bits"
+	"sort"
+)
+
+const (
+	// arrayMax is the largest cardinality stored in an array container.
+	arrayMax = 4096
+	// bitmapWords is the number of words in a bitmap container.
+	bitmapWords = 1 << 16 / 64
+	// runMax is the largest number of runs stored in a run container; more would take
+	// more space than a bitmap container.
+	runMax = 2047
+)
+
+// container holds the low 16 bits of the values sharing the same high 16 bits.
+// Methods that modify a container may return a different container holding the same
+// values in a more compact form.
+type container interface {
+	add(x uint16) (container, bool)
+	remove(x uint16) (container, bool)
+	contains(x uint16) bool
+	cardinality() int
+	// rank returns the number of values less than or equal to x.
+	rank(x uint16) int
+	// selectAt returns the value with i smaller values.
+	selectAt(i int) uint16
+	// each calls fn with every value in ascending order until fn returns false.
+	each(fn func(x uint16) bool) bool
+	toBitmap() *bitmapContainer
+	clone() container
+	// size returns the number of bytes used by the values.
+	size() int
+}
+
+// The arrayContainer struct holds up to arrayMax values in a sorted slice.
+type arrayContainer struct {
+	values []uint16
+}
+
+// search returns the index of the first value greater than or equal to x.
+func (a *arrayContainer) search(x uint16) int {
+	return sort.Search(len(a.values), func(i int) bool { return a.values[i] >= x })
+}
+
+func (a *arrayContainer) add(x uint16) (container, bool) {
+	i := a.search(x)
+	if i < len(a.values) && a.values[i] == x {
+		return a, false
+	}
+	if len(a.values) == arrayMax {
+		b := a.toBitmap()
+		b.add(x)
+		return b, true
+	}
+	a.values = append(a.values, 0)
+	copy(a.values[i+1:], a.values[i:])
+	a.values[i] = x
+	return a, true
+}
+
+func (a *arrayContainer) remove(x uint16) (container, bool) {
+	i := a.search(x)
+	if i == len(a.values) || a.values[i] != x {
+		return a, false
+	}
+	a.values = append(a.values[:i], a.values[i+1:]...)
+	return a, true
+}
+
+func (a *arrayContainer) contains(x uint16) bool {
+	i := a.search(x)
+	return i < len(a.values) && a.values[i] == x
+}
+
+func (a *arrayContainer) cardinality() int {
+	return len(a.values)
+}
+
+func (a *arrayContainer) rank(x uint16) int {
+	return sort.Search(len(a.values), func(i int) bool { return a.values[i] > x })
+}
+
+func (a *arrayContainer) selectAt(i int) uint16 {
+	return a.values[i]
+}
+
+func (a *arrayContainer) each(fn func(x uint16) bool) bool {
+	for _, x := range a.values {
+		if !fn(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *arrayContainer) toBitmap() *bitmapContainer {
+	b := newBitmapContainer()
+	for _, x := range a.values {
+		b.words[x/64] |= 1 << (x % 64)
+	}
+	b.card = len(a.values)
+	return b
+}
+
+func (a *arrayContainer) clone() container {
+	return &arrayContainer{values: append([]uint16(nil), a.values...)}
+}
+
+func (a *arrayContainer) size() int {
+	return 2 * len(a.values)
+}
+
+// The bitmapContainer struct holds values as one bit each.
+type bitmapContainer struct {
+	words []uint64
+	card  int
+}
+
+// newBitmapContainer creates an empty bitmap container.
+func newBitmapContainer() *bitmapContainer {
+	return &bitmapContainer{words: make([]uint64, bitmapWords)}
+}
+
+func (b *bitmapContainer) add(x uint16) (container, bool) {
+	word, mask := &b.words[x/64], uint64(1)<<(x%64)
+	if *word&mask != 0 {
+		return b, false
+	}
+	*word |= mask
+	b.card++
+	return b, true
+}
+
+func (b *bitmapContainer) remove(x uint16) (container, bool) {
+	word, mask := &b.words[x/64], uint64(1)<<(x%64)
+	if *word&mask == 0 {
+		return b, false
+	}
+	*word &^= mask
+	b.card--
+	if b.card <= arrayMax {
+		return b.toArray(), true
+	}
+	return b, true
+}
+
+func (b *bitmapContainer) contains(x uint16) bool {
+	return b.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.card
+}
+
+func (b *bitmapContainer) rank(x uint16) int {
+	rank := 0
+	for _, word := range b.words[:x/64] {
+		rank += bits.OnesCount64(word)
+	}
+	return rank + bits.OnesCount64(b.words[x/64]<<(63-x%64))
+}
+
+func (b *bitmapContainer) selectAt(i int) uint16 {
+	for w, word := range b.words {
+		n := bits.OnesCount64(word)
+		if i >= n {
+			i -= n
+			continue
+		}
+		for ; i > 0; i-- {
+			word &= word - 1
+		}
+		return uint16(w*64 + bits.TrailingZeros64(word))
+	}
+	panic("roaring: select out of range")
+}
+
+func (b *bitmapContainer) each(fn func(x uint16) bool) bool {
+	for w, word := range b.words {
+		for word != 0 {
+			if !fn(uint16(w*64 + bits.TrailingZeros64(word))) {
+				return false
+			}
+			word &= word - 1
+		}
+	}
+	return true
+}
+
+func (b *bitmapContainer) toBitmap() *bitmapContainer {
+	return b.clone().(*bitmapContainer)
+}
+
+// toArray returns an array container holding the values of b.
+func (b *bitmapContainer) toArray() *arrayContainer {
+	a := &arrayContainer{values: make([]uint16, 0, b.card)}
+	b.each(func(x uint16) bool {
+		a.values = append(a.values, x)
+		return true
+	})
+	return a
+}
+
+func (b *bitmapContainer) clone() container {
+	return &bitmapContainer{words: append([]uint64(nil), b.words...), card: b.card}
+}
+
+func (b *bitmapContainer) size() int {
+	return 8 * bitmapWords
+}
+
+// run represents the values from start to last inclusive.
+type run struct {
+	start, last uint16
+}
+
+// The runContainer struct holds values as sorted runs of consecutive values. Runs neither
+// overlap nor touch.
+type runContainer struct {
+	runs []run
+}
+
+// search returns the index of the first run ending at or after x.
+func (r *runContainer) search(x uint16) int {
+	return sort.Search(len(r.runs), func(i int) bool { return r.runs[i].last >= x })
+}
+
+func (r *runContainer) add(x uint16) (container, bool) {
+	i := r.search(x)
+	if i < len(r.runs) && r.runs[i].start <= x {
+		return r, false
+	}
+	joinLeft := i > 0 && r.runs[i-1].last+1 == x
+	joinRight := i < len(r.runs) && x+1 == r.runs[i].start
+	switch {
+	case joinLeft && joinRight:
+		r.runs[i-1].last = r.runs[i].last
+		r.runs = append(r.runs[:i], r.runs[i+1:]...)
+	case joinLeft:
+		r.runs[i-1].last = x
+	case joinRight:
+		r.runs[i].start = x
+	default:
+		r.runs = append(r.runs, run{})
+		copy(r.runs[i+1:], r.runs[i:])
+		r.runs[i] = run{start: x, last: x}
+		if len(r.runs) > runMax {
+			return normalize(r.toBitmap()), true
+		}
+	}
+	return r, true
+}
+
+func (r *runContainer) remove(x uint16) (container, bool) {
+	i := r.search(x)
+	if i == len(r.runs) || r.runs[i].start > x {
+		return r, false
+	}
+	current := r.runs[i]
+	switch {
+	case current.start == current.last:
+		r.runs = append(r.runs[:i], r.runs[i+1:]...)
+	case x == current.start:
+		r.runs[i].start++
+	case x == current.last:
+		r.runs[i].last--
+	default:
+		r.runs = append(r.runs, run{})
+		copy(r.runs[i+1:], r.runs[i:])
+		r.runs[i].last = x - 1
+		r.runs[i+1].start = x + 1
+		if len(r.runs) > runMax {
+			return normalize(r.toBitmap()), true
+		}
+	}
+	return r, true
+}
+
+func (r *runContainer) contains(x uint16) bool {
+	i := r.search(x)
+	return i < len(r.runs) && r.runs[i].start <= x
+}
+
+func (r *runContainer) cardinality() int {
+	card := 0
+	for _, current := range r.runs {
+		card += int(current.last-current.start) + 1
+	}
+	return card
+}
+
+func (r *runContainer) rank(x uint16) int {
+	rank := 0
+	for _, current := range r.runs {
+		if current.start > x {
+			break
+		}
+		rank += int(min(current.last, x)-current.start) + 1
+	}
+	return rank
+}
+
+func (r *runContainer) selectAt(i int) uint16 {
+	for _, current := range r.runs {
+		n := int(current.last-current.start) + 1
+		if i < n {
+			return current.start + uint16(i)
+		}
+		i -= n
+	}
+	panic("roaring: select out of range")
+}
+
+func (r *runContainer) each(fn func(x uint16) bool) bool {
+	for _, current := range r.runs {
+		for x := int(current.start); x <= int(current.last); x++ {
+			if !fn(uint16(x)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *runContainer) toBitmap() *bitmapContainer {
+	b := newBitmapContainer()
+	for _, current := range r.runs {
+		setRange(b.words, int(current.start), int(current.last)+1)
+	}
+	b.card = r.cardinality()
+	return b
+}
+
+func (r *runContainer) clone() container {
+	return &runContainer{runs: append([]run(nil), r.runs...)}
+}
+
+func (r *runContainer) size() int {
+	return 2 + 4*len(r.runs)
+}
+
+// setRange sets the bits in [from, to) of words.
+func setRange(words []uint64, from, to int) {
+	for from < to {
+		w := from / 64
+		mask := ^uint64(0) << (from % 64)
+		end := (w + 1) * 64
+		if to < end {
+			mask &= ^uint64(0) >> (end - to)
+			end = to
+		}
+		words[w] |= mask
+		from = end
+	}
+}
+
+// runsOf returns the runs of consecutive values of c.
+func runsOf(c container) []run {
+	var runs []run
+	c.each(func(x uint16) bool {
+		if n := len(runs); n > 0 && runs[n-1].last+1 == x {
+			runs[n-1].last = x
+		} else {
+			runs = append(runs, run{start: x, last: x})
+		}
+		return true
+	})
+	return runs
+}
+
+// normalize returns the values of b in an array container if there are few enough of them,
+// or nil if there are none.
+func normalize(b *bitmapContainer) container {
+	switch {
+	case b.card == 0:
+		return nil
+	case b.card <= arrayMax:
+		return b.toArray()
+	}
+	return b
+}
+
+// countRuns returns the number of runs of consecutive values of c without building them.
+func countRuns(c container) int {
+	switch c := c.(type) {
+	case *runContainer:
+		return len(c.runs)
+	case *bitmapContainer:
+		// A run starts at every set bit whose lower neighbour is clear.
+		count := 0
+		carry := uint64(0)
+		for _, word := range c.words {
+			count += bits.OnesCount64(word &^ (word<<1 | carry))
+			carry = word >> 63
+		}
+		return count
+	}
+	count := 0
+	c.each(func(x uint16) bool {
+		if count == 0 || !c.contains(x-1) {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// optimize returns the values of c in the most compact container.
+func optimize(c container) container {
+	var best container = c
+	if c.cardinality() <= arrayMax {
+		if _, ok := c.(*arrayContainer); !ok {
+			best = c.toBitmap().toArray()
+		}
+	} else if _, ok := c.(*bitmapContainer); !ok {
+		best = c.toBitmap()
+	}
+	if _, ok := c.(*runContainer); ok && c.size() < best.size() {
+		return c
+	}
+	if 2+4*countRuns(c) < best.size() {
+		return &runContainer{runs: runsOf(c)}
+	}
+	return best
+}
+
+// operation describes a set operation on containers.
+type operation struct {
+	// word combines the words of two bitmaps.
+	word func(a, b uint64) uint64
+	// onlyA, onlyB and both tell whether values found in only one or in both operands are kept.
+	onlyA, onlyB, both bool
+}
+
+var (
+	opAnd    = operation{word: func(a, b uint64) uint64 { return a & b }, both: true}
+	opOr     = operation{word: func(a, b uint64) uint64 { return a | b }, onlyA: true, onlyB: true, both: true}
+	opXor    = operation{word: func(a, b uint64) uint64 { return a ^ b }, onlyA: true, onlyB: true}
+	opAndNot = operation{word: func(a, b uint64) uint64 { return a &^ b }, onlyA: true}
+)
+
+// combine returns a new container holding the result of op on a and b, or nil if it is empty.
+// Two arrays are merged directly; any other pair is combined as bitmaps.
+func combine(a, b container, op operation) container {
+	x, okA := a.(*arrayContainer)
+	y, okB := b.(*arrayContainer)
+	if okA && okB {
+		values := make([]uint16, 0, len(x.values)+len(y.values))
+		i, j := 0, 0
+		for i < len(x.values) || j < len(y.values) {
+			switch {
+			case j == len(y.values) || (i < len(x.values) && x.values[i] < y.values[j]):
+				if op.onlyA {
+					values = append(values, x.values[i])
+				}
+				i++
+			case i == len(x.values) || y.values[j] < x.values[i]:
+				if op.onlyB {
+					values = append(values, y.values[j])
+				}
+				j++
+			default:
+				if op.both {
+					values = append(values, x.values[i])
+				}
+				i++
+				j++
+			}
+		}
+		result := &arrayContainer{values: values}
+		switch {
+		case len(values) == 0:
+			return nil
+		case len(values) > arrayMax:
+			return result.toBitmap()
+		}
+		return result
+	}
+	result := a.toBitmap()
+	other := b.toBitmap()
+	result.card = 0
+	for i := range result.words {
+		result.words[i] = op.word(result.words[i], other.words[i])
+		result.card += bits.OnesCount64(result.words[i])
+	}
+	return normalize(result)
+}
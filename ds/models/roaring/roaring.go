@@ -0,0 +1,506 @@
+// Description: This package contains the implementation of a compressed bitmap of 32-bit values
+// in the style of Roaring bitmaps.
+package roaring
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"sort"
+	"sync"
+)
+
+// ErrInvalidData is returned when unmarshaling data that does not hold a bitmap.
+var ErrInvalidData = errors.New("roaring: invalid data")
+
+// magic identifies the binary format of a bitmap.
+var magic = [4]byte{'G', 'R', 'B', '1'}
+
+// Container kinds in the binary format.
+const (
+	kindArray byte = iota
+	kindBitmap
+	kindRun
+)
+
+// The Bitmap struct represents a set of uint32 values. Values are grouped into chunks by
+// their high 16 bits, and each chunk is stored in a sorted array, a bitmap or a list of
+// runs, whichever the chunk's values call for.
+type Bitmap struct {
+	keys       []uint16
+	containers []container
+	mu         sync.RWMutex
+}
+
+// New creates a new empty bitmap.
+func New() *Bitmap {
+	return &Bitmap{}
+}
+
+// FromValues creates a new bitmap holding the given values.
+func FromValues(values ...uint32) *Bitmap {
+	b := New()
+	for _, x := range values {
+		b.add(x)
+	}
+	return b
+}
+
+// Unmarshal creates a new bitmap from data produced by MarshalBinary.
+func Unmarshal(data []byte) (*Bitmap, error) {
+	b := New()
+	if err := b.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// split returns the chunk key and the low bits of x.
+func split(x uint32) (uint16, uint16) {
+	return uint16(x >> 16), uint16(x)
+}
+
+// find returns the index of the container of the key and whether it exists.
+func (b *Bitmap) find(key uint16) (int, bool) {
+	i := sort.Search(len(b.keys), func(i int) bool { return b.keys[i] >= key })
+	return i, i < len(b.keys) && b.keys[i] == key
+}
+
+// insert adds the container of the key at index i.
+func (b *Bitmap) insert(i int, key uint16, c container) {
+	b.keys = append(b.keys, 0)
+	copy(b.keys[i+1:], b.keys[i:])
+	b.keys[i] = key
+	b.containers = append(b.containers, nil)
+	copy(b.containers[i+1:], b.containers[i:])
+	b.containers[i] = c
+}
+
+// drop removes the container at index i.
+func (b *Bitmap) drop(i int) {
+	b.keys = append(b.keys[:i], b.keys[i+1:]...)
+	b.containers = append(b.containers[:i], b.containers[i+1:]...)
+}
+
+// add adds x to the bitmap.
+func (b *Bitmap) add(x uint32) bool {
+	key, low := split(x)
+	i, ok := b.find(key)
+	if !ok {
+		b.insert(i, key, &arrayContainer{values: []uint16{low}})
+		return true
+	}
+	c, added := b.containers[i].add(low)
+	b.containers[i] = c
+	return added
+}
+
+// remove removes x from the bitmap.
+func (b *Bitmap) remove(x uint32) bool {
+	key, low := split(x)
+	i, ok := b.find(key)
+	if !ok {
+		return false
+	}
+	c, removed := b.containers[i].remove(low)
+	if c.cardinality() == 0 {
+		b.drop(i)
+	} else {
+		b.containers[i] = c
+	}
+	return removed
+}
+
+// cardinality returns the number of values.
+func (b *Bitmap) cardinality() int {
+	card := 0
+	for _, c := range b.containers {
+		card += c.cardinality()
+	}
+	return card
+}
+
+// clone returns a copy of the bitmap.
+func (b *Bitmap) clone() *Bitmap {
+	result := &Bitmap{
+		keys:       append([]uint16(nil), b.keys...),
+		containers: make([]container, len(b.containers)),
+	}
+	for i, c := range b.containers {
+		result.containers[i] = c.clone()
+	}
+	return result
+}
+
+// snapshot returns a copy of the bitmap in a concurrency-safe manner.
+func (b *Bitmap) snapshot() *Bitmap {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.clone()
+}
+
+// combine returns a new bitmap holding the result of op on b and other, in a concurrency-safe
+// manner. The other bitmap is copied first so that the two locks are never held together.
+func (b *Bitmap) combine(other *Bitmap, op operation) *Bitmap {
+	o := other.snapshot()
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	result := New()
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(o.keys) {
+		switch {
+		case j == len(o.keys) || (i < len(b.keys) && b.keys[i] < o.keys[j]):
+			if op.onlyA {
+				result.keys = append(result.keys, b.keys[i])
+				result.containers = append(result.containers, b.containers[i].clone())
+			}
+			i++
+		case i == len(b.keys) || o.keys[j] < b.keys[i]:
+			if op.onlyB {
+				result.keys = append(result.keys, o.keys[j])
+				result.containers = append(result.containers, o.containers[j])
+			}
+			j++
+		default:
+			if c := combine(b.containers[i], o.containers[j], op); c != nil {
+				result.keys = append(result.keys, b.keys[i])
+				result.containers = append(result.containers, c)
+			}
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Add adds x to the bitmap in a concurrency-safe manner.
+// It returns false if x was already present.
+func (b *Bitmap) Add(x uint32) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.add(x)
+}
+
+// AddRange adds the values in [from, to) to the bitmap in a concurrency-safe manner.
+// The range is stored as runs, so large ranges take little space.
+func (b *Bitmap) AddRange(from, to uint64) {
+	to = min(to, 1<<32)
+	if from >= to {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for start := from; start < to; {
+		key := uint16(start >> 16)
+		end := min(to, (start>>16+1)<<16)
+		r := &runContainer{runs: []run{{start: uint16(start), last: uint16(end - 1)}}}
+		i, ok := b.find(key)
+		if ok {
+			b.containers[i] = optimize(combine(b.containers[i], r, opOr))
+		} else {
+			b.insert(i, key, r)
+		}
+		start = end
+	}
+}
+
+// Remove removes x from the bitmap in a concurrency-safe manner.
+// It returns false if x was not present.
+func (b *Bitmap) Remove(x uint32) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.remove(x)
+}
+
+// Contains returns true if x is in the bitmap, in a concurrency-safe manner.
+func (b *Bitmap) Contains(x uint32) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	key, low := split(x)
+	i, ok := b.find(key)
+	return ok && b.containers[i].contains(low)
+}
+
+// Cardinality returns the number of values in the bitmap in a concurrency-safe manner.
+func (b *Bitmap) Cardinality() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.cardinality()
+}
+
+// IsEmpty returns true if the bitmap holds no values, in a concurrency-safe manner.
+func (b *Bitmap) IsEmpty() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.containers) == 0
+}
+
+// Rank returns the number of values less than or equal to x in a concurrency-safe manner.
+func (b *Bitmap) Rank(x uint32) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	key, low := split(x)
+	rank := 0
+	for i, k := range b.keys {
+		if k > key {
+			break
+		}
+		if k < key {
+			rank += b.containers[i].cardinality()
+		} else {
+			rank += b.containers[i].rank(low)
+		}
+	}
+	return rank
+}
+
+// Select returns the value with i smaller values in the bitmap, in a concurrency-safe manner.
+// It returns false if the bitmap holds i values or fewer.
+func (b *Bitmap) Select(i int) (uint32, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if i < 0 {
+		return 0, false
+	}
+	for n, c := range b.containers {
+		card := c.cardinality()
+		if i < card {
+			return uint32(b.keys[n])<<16 | uint32(c.selectAt(i)), true
+		}
+		i -= card
+	}
+	return 0, false
+}
+
+// Min returns the smallest value in a concurrency-safe manner.
+// It returns false if the bitmap is empty.
+func (b *Bitmap) Min() (uint32, bool) {
+	return b.Select(0)
+}
+
+// Max returns the largest value in a concurrency-safe manner.
+// It returns false if the bitmap is empty.
+func (b *Bitmap) Max() (uint32, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	n := len(b.containers)
+	if n == 0 {
+		return 0, false
+	}
+	c := b.containers[n-1]
+	return uint32(b.keys[n-1])<<16 | uint32(c.selectAt(c.cardinality()-1)), true
+}
+
+// Range returns a channel that iterates over the values in ascending order in a concurrency-safe manner.
+func (b *Bitmap) Range() <-chan uint32 {
+	b.mu.RLock()
+	ch := make(chan uint32)
+	go func() {
+		defer b.mu.RUnlock()
+		for i, c := range b.containers {
+			high := uint32(b.keys[i]) << 16
+			c.each(func(x uint16) bool {
+				ch <- high | uint32(x)
+				return true
+			})
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// ToSlice returns the values in ascending order in a concurrency-safe manner.
+func (b *Bitmap) ToSlice() []uint32 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	values := make([]uint32, 0, b.cardinality())
+	for i, c := range b.containers {
+		high := uint32(b.keys[i]) << 16
+		c.each(func(x uint16) bool {
+			values = append(values, high|uint32(x))
+			return true
+		})
+	}
+	return values
+}
+
+// Clone returns a copy of the bitmap in a concurrency-safe manner.
+func (b *Bitmap) Clone() *Bitmap {
+	return b.snapshot()
+}
+
+// Equal returns true if both bitmaps hold the same values, in a concurrency-safe manner.
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	return b.combine(other, opXor).IsEmpty()
+}
+
+// And returns a new bitmap holding the values in both b and other, in a concurrency-safe manner.
+func (b *Bitmap) And(other *Bitmap) *Bitmap {
+	return b.combine(other, opAnd)
+}
+
+// Or returns a new bitmap holding the values in b or other, in a concurrency-safe manner.
+func (b *Bitmap) Or(other *Bitmap) *Bitmap {
+	return b.combine(other, opOr)
+}
+
+// Xor returns a new bitmap holding the values in exactly one of b and other, in a concurrency-safe manner.
+func (b *Bitmap) Xor(other *Bitmap) *Bitmap {
+	return b.combine(other, opXor)
+}
+
+// AndNot returns a new bitmap holding the values in b but not in other, in a concurrency-safe manner.
+func (b *Bitmap) AndNot(other *Bitmap) *Bitmap {
+	return b.combine(other, opAndNot)
+}
+
+// RunOptimize converts every container to its most compact form in a concurrency-safe manner.
+// Bitmaps holding long sequences of consecutive values benefit the most.
+func (b *Bitmap) RunOptimize() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+// SizeInBytes returns the number of bytes used by the values in a concurrency-safe manner.
+func (b *Bitmap) SizeInBytes() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	size := 0
+	for _, c := range b.containers {
+		size += 2 + c.size()
+	}
+	return size
+}
+
+// MarshalBinary encodes the bitmap in a concurrency-safe manner.
+//
+// The format is the magic "GRB1" and the number of containers as a little-endian uint32,
+// followed by each container in ascending key order: its key as a uint16, its kind as a byte
+// (0 array, 1 bitmap, 2 run) and a uint32 count, then its payload. An array holds count
+// uint16 values; a bitmap holds count values in 1024 uint64 words; a run container holds
+// count pairs of uint16 start and last values. All integers are little-endian.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	data := append(magic[:len(magic):len(magic)], 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(data[4:], uint32(len(b.containers)))
+	for i, c := range b.containers {
+		data = binary.LittleEndian.AppendUint16(data, b.keys[i])
+		switch c := c.(type) {
+		case *arrayContainer:
+			data = append(data, kindArray)
+			data = binary.LittleEndian.AppendUint32(data, uint32(len(c.values)))
+			for _, x := range c.values {
+				data = binary.LittleEndian.AppendUint16(data, x)
+			}
+		case *bitmapContainer:
+			data = append(data, kindBitmap)
+			data = binary.LittleEndian.AppendUint32(data, uint32(c.card))
+			for _, word := range c.words {
+				data = binary.LittleEndian.AppendUint64(data, word)
+			}
+		case *runContainer:
+			data = append(data, kindRun)
+			data = binary.LittleEndian.AppendUint32(data, uint32(len(c.runs)))
+			for _, current := range c.runs {
+				data = binary.LittleEndian.AppendUint16(data, current.start)
+				data = binary.LittleEndian.AppendUint16(data, current.last)
+			}
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the bitmap with data produced by MarshalBinary,
+// in a concurrency-safe manner.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < 8 || [4]byte(data[:4]) != magic {
+		return ErrInvalidData
+	}
+	n := binary.LittleEndian.Uint32(data[4:])
+	if n > 1<<16 {
+		return ErrInvalidData
+	}
+	data = data[8:]
+	keys := make([]uint16, 0, n)
+	containers := make([]container, 0, n)
+	for i := uint32(0); i < n; i++ {
+		if len(data) < 7 {
+			return ErrInvalidData
+		}
+		key := binary.LittleEndian.Uint16(data)
+		kind := data[2]
+		count := int(binary.LittleEndian.Uint32(data[3:]))
+		data = data[7:]
+		if len(keys) > 0 && key <= keys[len(keys)-1] {
+			return ErrInvalidData
+		}
+		c, rest, ok := decodeContainer(kind, count, data)
+		if !ok {
+			return ErrInvalidData
+		}
+		keys = append(keys, key)
+		containers = append(containers, c)
+		data = rest
+	}
+	if len(data) != 0 {
+		return ErrInvalidData
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.keys, b.containers = keys, containers
+	return nil
+}
+
+// decodeContainer decodes a container of the given kind and count from the start of data
+// and returns the remaining data.
+func decodeContainer(kind byte, count int, data []byte) (container, []byte, bool) {
+	switch kind {
+	case kindArray:
+		if count == 0 || count > arrayMax || len(data) < 2*count {
+			return nil, nil, false
+		}
+		values := make([]uint16, count)
+		for i := range values {
+			values[i] = binary.LittleEndian.Uint16(data[2*i:])
+			if i > 0 && values[i] <= values[i-1] {
+				return nil, nil, false
+			}
+		}
+		return &arrayContainer{values: values}, data[2*count:], true
+	case kindBitmap:
+		if count == 0 || len(data) < 8*bitmapWords {
+			return nil, nil, false
+		}
+		c := newBitmapContainer()
+		card := 0
+		for i := range c.words {
+			c.words[i] = binary.LittleEndian.Uint64(data[8*i:])
+			card += bits.OnesCount64(c.words[i])
+		}
+		if card != count {
+			return nil, nil, false
+		}
+		c.card = card
+		return c, data[8*bitmapWords:], true
+	case kindRun:
+		if count == 0 || count > runMax || len(data) < 4*count {
+			return nil, nil, false
+		}
+		runs := make([]run, count)
+		for i := range runs {
+			runs[i] = run{
+				start: binary.LittleEndian.Uint16(data[4*i:]),
+				last:  binary.LittleEndian.Uint16(data[4*i+2:]),
+			}
+			if runs[i].start > runs[i].last || (i > 0 && int(runs[i].start) <= int(runs[i-1].last)+1) {
+				return nil, nil, false
+			}
+		}
+		return &runContainer{runs: runs}, data[4*count:], true
+	}
+	return nil, nil, false
+}
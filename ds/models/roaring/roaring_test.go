@@ -0,0 +1,212 @@
+package roaring_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/roaring"
+)
+
+// reference is a map-based set used to check bitmaps against.
+type reference map[uint32]bool
+
+// sorted returns the values of the reference set in ascending order.
+func (r reference) sorted() []uint32 {
+	values := make([]uint32, 0, len(r))
+	for x := range r {
+		values = append(values, x)
+	}
+	slices.Sort(values)
+	return values
+}
+
+// randomValue returns a value that is sparse, dense or clustered in runs, so that every
+// kind of container is exercised.
+func randomValue(rng *rand.Rand) uint32 {
+	switch rng.Intn(3) {
+	case 0:
+		return rng.Uint32()
+	case 1:
+		return 1<<16 | uint32(rng.Intn(1<<16))
+	}
+	return 2<<16 | uint32(rng.Intn(64))*1000 + uint32(rng.Intn(200))
+}
+
+// check compares the bitmap with the reference set.
+func check(t *testing.T, b *roaring.Bitmap, r reference) {
+	t.Helper()
+	expected := r.sorted()
+	if b.Cardinality() != len(expected) {
+		t.Fatalf("Expected cardinality %d, got %d", len(expected), b.Cardinality())
+	}
+	if values := b.ToSlice(); !slices.Equal(values, expected) {
+		t.Fatal("Expected the bitmap values to match the reference")
+	}
+	for i, x := range expected {
+		if i%499 != 0 {
+			continue
+		}
+		if !b.Contains(x) {
+			t.Fatalf("Expected bitmap to contain %d", x)
+		}
+		if rank := b.Rank(x); rank != i+1 {
+			t.Fatalf("Expected Rank(%d) = %d, got %d", x, i+1, rank)
+		}
+		if value, ok := b.Select(i); !ok || value != x {
+			t.Fatalf("Expected Select(%d) = %d, got %d", i, x, value)
+		}
+	}
+	if _, ok := b.Select(len(expected)); ok {
+		t.Fatal("Expected Select past the cardinality to fail")
+	}
+}
+
+func TestBitmapRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	b := roaring.New()
+	r := make(reference)
+	for n := 0; n < 20000; n++ {
+		x := randomValue(rng)
+		switch rng.Intn(10) {
+		case 0, 1, 2:
+			if b.Remove(x) != r[x] {
+				t.Fatalf("Unexpected result removing %d", x)
+			}
+			delete(r, x)
+		case 3:
+			length := uint64(rng.Intn(1000))
+			b.AddRange(uint64(x), uint64(x)+length)
+			for y := uint64(x); y < uint64(x)+length && y < 1<<32; y++ {
+				r[uint32(y)] = true
+			}
+		default:
+			if b.Add(x) == r[x] {
+				t.Fatalf("Unexpected result adding %d", x)
+			}
+			r[x] = true
+		}
+		if n%5000 == 0 {
+			check(t, b, r)
+		}
+	}
+	check(t, b, r)
+	b.RunOptimize()
+	check(t, b, r)
+	for _, x := range r.sorted() {
+		b.Remove(x)
+	}
+	if !b.IsEmpty() {
+		t.Error("Expected the bitmap to be empty after removing every value")
+	}
+}
+
+func TestBitmapOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	a, b := roaring.New(), roaring.New()
+	ra, rb := make(reference), make(reference)
+	for n := 0; n < 20000; n++ {
+		x, y := randomValue(rng), randomValue(rng)
+		a.Add(x)
+		ra[x] = true
+		b.Add(y)
+		rb[y] = true
+	}
+	a.AddRange(3<<16, 3<<16+50000)
+	for x := uint32(3 << 16); x < 3<<16+50000; x++ {
+		ra[x] = true
+	}
+	tests := []struct {
+		name   string
+		result *roaring.Bitmap
+		keep   func(inA, inB bool) bool
+	}{
+		{name: "And", result: a.And(b), keep: func(inA, inB bool) bool { return inA && inB }},
+		{name: "Or", result: a.Or(b), keep: func(inA, inB bool) bool { return inA || inB }},
+		{name: "Xor", result: a.Xor(b), keep: func(inA, inB bool) bool { return inA != inB }},
+		{name: "AndNot", result: a.AndNot(b), keep: func(inA, inB bool) bool { return inA && !inB }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := make(reference)
+			for _, r := range []reference{ra, rb} {
+				for x := range r {
+					if test.keep(ra[x], rb[x]) {
+						expected[x] = true
+					}
+				}
+			}
+			check(t, test.result, expected)
+		})
+	}
+	check(t, a, ra)
+	check(t, b, rb)
+	if !a.Equal(a.Clone()) || a.Equal(b) {
+		t.Error("Expected Equal to compare values")
+	}
+}
+
+func TestBitmapRunOptimize(t *testing.T) {
+	b := roaring.New()
+	for x := uint32(0); x < 200000; x++ {
+		b.Add(x)
+	}
+	before := b.SizeInBytes()
+	b.RunOptimize()
+	if after := b.SizeInBytes(); after >= before/100 {
+		t.Errorf("Expected run containers to shrink %d bytes, got %d", before, after)
+	}
+	b.Remove(1000)
+	if b.Contains(1000) || b.Cardinality() != 199999 {
+		t.Error("Expected Remove to split a run")
+	}
+	if x, ok := b.Max(); !ok || x != 199999 {
+		t.Errorf("Expected max 199999, got %d", x)
+	}
+	if x, ok := b.Min(); !ok || x != 0 {
+		t.Errorf("Expected min 0, got %d", x)
+	}
+}
+
+func TestBitmapMarshal(t *testing.T) {
+	b := roaring.FromValues(1, 5, 1<<16)
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expected := []byte{
+		'G', 'R', 'B', '1', 2, 0, 0, 0,
+		0, 0, 0, 2, 0, 0, 0, 1, 0, 5, 0,
+		1, 0, 0, 1, 0, 0, 0, 0, 0,
+	}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Expected encoding %v, got %v", expected, data)
+	}
+
+	rng := rand.New(rand.NewSource(3))
+	b = roaring.New()
+	for n := 0; n < 20000; n++ {
+		b.Add(randomValue(rng))
+	}
+	b.AddRange(5<<16, 6<<16)
+	b.RunOptimize()
+	data, _ = b.MarshalBinary()
+	loaded, err := roaring.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !loaded.Equal(b) {
+		t.Error("Expected the bitmap to survive a round trip")
+	}
+	if again, _ := loaded.MarshalBinary(); !bytes.Equal(again, data) {
+		t.Error("Expected the encoding to be stable")
+	}
+	if _, err := roaring.Unmarshal(data[:len(data)-1]); !errors.Is(err, roaring.ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for truncated data, got %v", err)
+	}
+	if _, err := roaring.Unmarshal([]byte("junk")); !errors.Is(err, roaring.ErrInvalidData) {
+		t.Errorf("Expected ErrInvalidData for junk, got %v", err)
+	}
+}
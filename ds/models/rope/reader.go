@@ -0,0 +1,50 @@
+package rope
+
+import "io"
+
+// The Reader struct reads the text of a rope as it was when the reader was created.
+// Later edits of the rope do not affect it.
+type Reader struct {
+	stack   []*node
+	current string
+}
+
+// Reader returns a reader of the text in a concurrency-safe manner.
+func (r *Rope) Reader() *Reader {
+	reader := &Reader{}
+	if root := r.snapshot(); root != nil {
+		reader.stack = append(reader.stack, root)
+	}
+	return reader
+}
+
+// next loads the text of the next leaf. It returns false once every leaf has been read.
+func (r *Reader) next() bool {
+	for len(r.stack) > 0 {
+		n := r.stack[len(r.stack)-1]
+		r.stack = r.stack[:len(r.stack)-1]
+		if n.left == nil {
+			r.current = n.text
+			return true
+		}
+		r.stack = append(r.stack, n.right, n.left)
+	}
+	return false
+}
+
+// Read implements io.Reader.
+func (r *Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if r.current == "" && !r.next() {
+			break
+		}
+		copied := copy(p[n:], r.current)
+		r.current = r.current[copied:]
+		n += copied
+	}
+	if n == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,481 @@
+// Description: This package contains the implementation of a rope for editing large texts.
+package rope
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxLeaf is the largest number of bytes stored in one leaf when building or joining.
+const maxLeaf = 512
+
+// node is an immutable piece of a rope. Leaves hold text; inner nodes hold the
+// concatenation of their children and are kept height-balanced as in an AVL tree.
+// Since nodes never change, ropes share them freely.
+type node struct {
+	left, right *node
+	text        string
+	bytes       int
+	runes       int
+	lines       int
+	height      int
+	leaves      int
+}
+
+// newLeaf creates a leaf holding the text, or nil if the text is empty.
+func newLeaf(text string) *node {
+	if text == "" {
+		return nil
+	}
+	return &node{
+		text:   text,
+		bytes:  len(text),
+		runes:  utf8.RuneCountInString(text),
+		lines:  strings.Count(text, "\n"),
+		leaves: 1,
+	}
+}
+
+// newNode creates an inner node holding the concatenation of left and right.
+func newNode(left, right *node) *node {
+	return &node{
+		left:   left,
+		right:  right,
+		bytes:  left.bytes + right.bytes,
+		runes:  left.runes + right.runes,
+		lines:  left.lines + right.lines,
+		height: max(left.height, right.height) + 1,
+		leaves: left.leaves + right.leaves,
+	}
+}
+
+// length returns the number of runes of n, which may be nil.
+func (n *node) length() int {
+	if n == nil {
+		return 0
+	}
+	return n.runes
+}
+
+// balance returns the concatenation of left and right, whose heights differ by at most two,
+// rotating once or twice to restore the height balance.
+func balance(left, right *node) *node {
+	switch {
+	case left.height > right.height+1:
+		if left.left.height >= left.right.height {
+			return newNode(left.left, newNode(left.right, right))
+		}
+		inner := left.right
+		return newNode(newNode(left.left, inner.left), newNode(inner.right, right))
+	case right.height > left.height+1:
+		if right.right.height >= right.left.height {
+			return newNode(newNode(left, right.left), right.right)
+		}
+		inner := right.left
+		return newNode(newNode(left, inner.left), newNode(inner.right, right.right))
+	}
+	return newNode(left, right)
+}
+
+// join returns the concatenation of left and right in O(|height(left) - height(right)|).
+func join(left, right *node) *node {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.left == nil && right.left == nil && left.bytes+right.bytes <= maxLeaf:
+		return newLeaf(left.text + right.text)
+	case left.height > right.height+1:
+		return balance(left.left, join(left.right, right))
+	case right.height > left.height+1:
+		return balance(join(left, right.left), right.right)
+	}
+	return newNode(left, right)
+}
+
+// split returns the first i runes of n and the rest.
+func split(n *node, i int) (*node, *node) {
+	switch {
+	case n == nil:
+		return nil, nil
+	case i <= 0:
+		return nil, n
+	case i >= n.runes:
+		return n, nil
+	case n.left == nil:
+		b := byteOffset(n.text, i)
+		return newLeaf(n.text[:b]), newLeaf(n.text[b:])
+	case i <= n.left.runes:
+		l, r := split(n.left, i)
+		return l, join(r, n.right)
+	}
+	l, r := split(n.right, i-n.left.runes)
+	return join(n.left, l), r
+}
+
+// byteOffset returns the byte offset of rune i in text.
+func byteOffset(text string, i int) int {
+	for b := range text {
+		if i == 0 {
+			return b
+		}
+		i--
+	}
+	return len(text)
+}
+
+// build returns a balanced rope holding the text, cut into leaves at rune boundaries.
+func build(text string) *node {
+	var leaves []*node
+	for len(text) > 0 {
+		end := min(len(text), maxLeaf)
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		if end == 0 {
+			end = min(len(text), maxLeaf)
+		}
+		leaves = append(leaves, newLeaf(text[:end]))
+		text = text[end:]
+	}
+	return buildLeaves(leaves)
+}
+
+// buildLeaves returns a balanced rope holding the leaves in order.
+func buildLeaves(leaves []*node) *node {
+	switch len(leaves) {
+	case 0:
+		return nil
+	case 1:
+		return leaves[0]
+	}
+	mid := len(leaves) / 2
+	return newNode(buildLeaves(leaves[:mid]), buildLeaves(leaves[mid:]))
+}
+
+// each calls fn with the text of every leaf of n in order until fn returns false.
+func (n *node) each(fn func(text string) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.left == nil {
+		return fn(n.text)
+	}
+	return n.left.each(fn) && n.right.each(fn)
+}
+
+// String returns the text of n.
+func (n *node) String() string {
+	var sb strings.Builder
+	if n != nil {
+		sb.Grow(n.bytes)
+	}
+	n.each(func(text string) bool {
+		sb.WriteString(text)
+		return true
+	})
+	return sb.String()
+}
+
+// compact returns n rebuilt with adjacent small leaves merged and a minimal height.
+func compact(n *node) *node {
+	var leaves []*node
+	var pending strings.Builder
+	n.each(func(text string) bool {
+		if pending.Len()+len(text) > maxLeaf && pending.Len() > 0 {
+			leaves = append(leaves, newLeaf(pending.String()))
+			pending.Reset()
+		}
+		pending.WriteString(text)
+		return true
+	})
+	if pending.Len() > 0 {
+		leaves = append(leaves, newLeaf(pending.String()))
+	}
+	return buildLeaves(leaves)
+}
+
+// fragmented reports whether n has many more leaves than its text needs, which makes
+// edits and iteration slower than necessary.
+func fragmented(n *node) bool {
+	return n != nil && n.leaves > 4*(n.bytes/maxLeaf)+64
+}
+
+// runeAt returns rune i of n.
+func (n *node) runeAt(i int) rune {
+	for n.left != nil {
+		if i < n.left.runes {
+			n = n.left
+		} else {
+			i -= n.left.runes
+			n = n.right
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(n.text[byteOffset(n.text, i):])
+	return r
+}
+
+// lineStart returns the rune offset following the k-th newline of n, counting from one.
+func (n *node) lineStart(k int) int {
+	offset := 0
+	for n.left != nil {
+		if k <= n.left.lines {
+			n = n.left
+		} else {
+			k -= n.left.lines
+			offset += n.left.runes
+			n = n.right
+		}
+	}
+	i := 0
+	for _, r := range n.text {
+		i++
+		if r == '\n' {
+			if k--; k == 0 {
+				break
+			}
+		}
+	}
+	return offset + i
+}
+
+// lineOf returns the number of newlines among the first i runes of n.
+func (n *node) lineOf(i int) int {
+	lines := 0
+	for n.left != nil {
+		if i < n.left.runes {
+			n = n.left
+		} else {
+			i -= n.left.runes
+			lines += n.left.lines
+			n = n.right
+		}
+	}
+	return lines + strings.Count(n.text[:byteOffset(n.text, i)], "\n")
+}
+
+// The Rope struct represents a text stored as a balanced tree of immutable chunks.
+// Positions are counted in runes. Insertions and deletions take O(log n) time, and the
+// tree is compacted once edits have split it into many small chunks.
+type Rope struct {
+	root *node
+	mu   sync.RWMutex
+}
+
+// New creates a new rope holding the text.
+func New(text string) *Rope {
+	return &Rope{root: build(text)}
+}
+
+// setRoot replaces the root, compacting the tree if it has become fragmented.
+func (r *Rope) setRoot(root *node) {
+	if fragmented(root) {
+		root = compact(root)
+	}
+	r.root = root
+}
+
+// snapshot returns the root in a concurrency-safe manner.
+func (r *Rope) snapshot() *node {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.root
+}
+
+// Length returns the number of runes in a concurrency-safe manner.
+func (r *Rope) Length() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.root.length()
+}
+
+// Size returns the number of bytes in a concurrency-safe manner.
+func (r *Rope) Size() int {
+	root := r.snapshot()
+	if root == nil {
+		return 0
+	}
+	return root.bytes
+}
+
+// IsEmpty returns true if the rope holds no text, in a concurrency-safe manner.
+func (r *Rope) IsEmpty() bool {
+	return r.snapshot() == nil
+}
+
+// Insert inserts the text before rune i in a concurrency-safe manner.
+// It returns false if i is out of range.
+func (r *Rope) Insert(i int, text string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i < 0 || i > r.root.length() {
+		return false
+	}
+	left, right := split(r.root, i)
+	r.setRoot(join(join(left, build(text)), right))
+	return true
+}
+
+// Append adds the text at the end of the rope in a concurrency-safe manner.
+func (r *Rope) Append(text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.setRoot(join(r.root, build(text)))
+}
+
+// Delete removes the runes in [from, to) in a concurrency-safe manner.
+// It returns false if the range is invalid.
+func (r *Rope) Delete(from, to int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if from < 0 || from > to || to > r.root.length() {
+		return false
+	}
+	left, rest := split(r.root, from)
+	_, right := split(rest, to-from)
+	r.setRoot(join(left, right))
+	return true
+}
+
+// Index returns rune i in a concurrency-safe manner.
+// It returns false if i is out of range.
+func (r *Rope) Index(i int) (rune, bool) {
+	root := r.snapshot()
+	if root == nil || i < 0 || i >= root.runes {
+		return 0, false
+	}
+	return root.runeAt(i), true
+}
+
+// Slice returns the runes in [from, to) as a string in a concurrency-safe manner.
+// It returns false if the range is invalid.
+func (r *Rope) Slice(from, to int) (string, bool) {
+	root := r.snapshot()
+	if from < 0 || from > to || to > root.length() {
+		return "", false
+	}
+	return slice(root, from, to), true
+}
+
+// slice returns the runes of n in [from, to) as a string.
+func slice(n *node, from, to int) string {
+	_, rest := split(n, from)
+	middle, _ := split(rest, to-from)
+	return middle.String()
+}
+
+// String returns the whole text in a concurrency-safe manner.
+func (r *Rope) String() string {
+	return r.snapshot().String()
+}
+
+// Concat appends the text of other to r in a concurrency-safe manner. The other rope is
+// left unchanged; both share the appended chunks.
+func (r *Rope) Concat(other *Rope) {
+	root := other.snapshot()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.setRoot(join(r.root, root))
+}
+
+// Split returns two new ropes holding the first i runes and the rest, in a concurrency-safe
+// manner. The rope is left unchanged. It returns false if i is out of range.
+func (r *Rope) Split(i int) (*Rope, *Rope, bool) {
+	root := r.snapshot()
+	if i < 0 || i > root.length() {
+		return nil, nil, false
+	}
+	left, right := split(root, i)
+	return &Rope{root: left}, &Rope{root: right}, true
+}
+
+// Clone returns a copy of the rope in O(1) in a concurrency-safe manner.
+func (r *Rope) Clone() *Rope {
+	return &Rope{root: r.snapshot()}
+}
+
+// Rebalance rebuilds the rope with merged chunks and minimal height in a concurrency-safe manner.
+// Ropes rebalance themselves as they are edited; this is only useful before a long read-only phase.
+func (r *Rope) Rebalance() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.root = compact(r.root)
+}
+
+// LineCount returns the number of lines in a concurrency-safe manner. A text with n
+// newlines has n+1 lines, the last of which may be empty.
+func (r *Rope) LineCount() int {
+	root := r.snapshot()
+	if root == nil {
+		return 1
+	}
+	return root.lines + 1
+}
+
+// lineBounds returns the offsets of the first rune of line k of n and of its trailing
+// newline, or of the end of the text for the last line.
+func lineBounds(n *node, k int) (int, int, bool) {
+	lines := 0
+	if n != nil {
+		lines = n.lines
+	}
+	if k < 0 || k > lines {
+		return 0, 0, false
+	}
+	start, end := 0, n.length()
+	if k > 0 {
+		start = n.lineStart(k)
+	}
+	if k < lines {
+		end = n.lineStart(k+1) - 1
+	}
+	return start, end, true
+}
+
+// LineStart returns the offset of the first rune of line k, counting from zero, in a
+// concurrency-safe manner. It returns false if there is no such line.
+func (r *Rope) LineStart(k int) (int, bool) {
+	start, _, ok := lineBounds(r.snapshot(), k)
+	return start, ok
+}
+
+// LineOf returns the line holding rune i, counting from zero, in a concurrency-safe manner.
+// Offset Length() belongs to the last line. It returns false if i is out of range.
+func (r *Rope) LineOf(i int) (int, bool) {
+	root := r.snapshot()
+	if i < 0 || i > root.length() {
+		return 0, false
+	}
+	if root == nil {
+		return 0, true
+	}
+	return root.lineOf(i), true
+}
+
+// Line returns line k without its trailing newline in a concurrency-safe manner.
+// It returns false if there is no such line.
+func (r *Rope) Line(k int) (string, bool) {
+	root := r.snapshot()
+	start, end, ok := lineBounds(root, k)
+	if !ok {
+		return "", false
+	}
+	return slice(root, start, end), true
+}
+
+// Range returns a channel that iterates over the chunks of text in order in a concurrency-safe manner.
+// The chunks are those of the rope when Range was called.
+func (r *Rope) Range() <-chan string {
+	root := r.snapshot()
+	ch := make(chan string)
+	go func() {
+		root.each(func(text string) bool {
+			ch <- text
+			return true
+		})
+		close(ch)
+	}()
+	return ch
+}
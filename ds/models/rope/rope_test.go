@@ -0,0 +1,208 @@
+package rope
+
+import (
+	"io"
+	"math/bits"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+)
+
+// checkInvariants verifies the cached sizes and the height balance of every node.
+func checkInvariants(t *testing.T, n *node) {
+	t.Helper()
+	if n == nil || n.left == nil {
+		return
+	}
+	if d := n.left.height - n.right.height; d < -1 || d > 1 {
+		t.Fatalf("Expected balanced children, got heights %d and %d", n.left.height, n.right.height)
+	}
+	if n.runes != n.left.runes+n.right.runes || n.lines != n.left.lines+n.right.lines ||
+		n.height != max(n.left.height, n.right.height)+1 {
+		t.Fatal("Expected cached sizes to match the children")
+	}
+	checkInvariants(t, n.left)
+	checkInvariants(t, n.right)
+}
+
+func TestRopeEdits(t *testing.T) {
+	r := New("Hello, world!")
+	r.Insert(7, "wide ")
+	r.Delete(0, 7)
+	r.Insert(0, "Goodbye, ")
+	r.Append(" ✓")
+	if s := r.String(); s != "Goodbye, wide world! ✓" {
+		t.Errorf("Expected edited text, got %q", s)
+	}
+	if r.Length() != 22 || r.Size() != 24 {
+		t.Errorf("Expected 22 runes in 24 bytes, got %d in %d", r.Length(), r.Size())
+	}
+	if c, ok := r.Index(21); !ok || c != '✓' {
+		t.Errorf("Expected last rune ✓, got %q", c)
+	}
+	if s, ok := r.Slice(9, 13); !ok || s != "wide" {
+		t.Errorf("Expected slice wide, got %q", s)
+	}
+	for _, ok := range []bool{r.Insert(-1, "x"), r.Insert(23, "x"), r.Delete(5, 4), r.Delete(0, 23)} {
+		if ok {
+			t.Error("Expected out-of-range edits to fail")
+		}
+	}
+	if _, ok := r.Index(22); ok {
+		t.Error("Expected Index past the end to fail")
+	}
+}
+
+func TestRopeRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	alphabet := []rune("abc\nxyzé日本")
+	randomText := func(n int) string {
+		runes := make([]rune, n)
+		for i := range runes {
+			runes[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+		return string(runes)
+	}
+	r := New("")
+	var reference []rune
+	for n := 0; n < 5000; n++ {
+		if rng.Intn(3) > 0 || len(reference) == 0 {
+			i := rng.Intn(len(reference) + 1)
+			text := randomText(rng.Intn(40))
+			if !r.Insert(i, text) {
+				t.Fatalf("Insert at %d failed", i)
+			}
+			reference = append(reference[:i], append([]rune(text), reference[i:]...)...)
+		} else {
+			from := rng.Intn(len(reference))
+			to := from + rng.Intn(min(30, len(reference)-from)+1)
+			if !r.Delete(from, to) {
+				t.Fatalf("Delete of [%d, %d) failed", from, to)
+			}
+			reference = append(reference[:from], reference[to:]...)
+		}
+		if n%500 == 0 {
+			checkInvariants(t, r.root)
+			if r.String() != string(reference) {
+				t.Fatalf("Rope diverged from the reference after %d edits", n)
+			}
+		}
+	}
+	checkInvariants(t, r.root)
+	if r.Length() != len(reference) || r.String() != string(reference) {
+		t.Fatal("Expected the rope to match the reference")
+	}
+	for n := 0; n < 200; n++ {
+		i := rng.Intn(len(reference))
+		if c, _ := r.Index(i); c != reference[i] {
+			t.Fatalf("Expected rune %q at %d, got %q", reference[i], i, c)
+		}
+	}
+	if fragmented(r.root) {
+		t.Error("Expected edits to keep the rope compact")
+	}
+	// An AVL tree over n leaves is at most about 1.44 log2(n) high.
+	if bound := 3 * bits.Len(uint(r.root.leaves)) / 2; r.root.height > bound {
+		t.Errorf("Expected height at most %d, got %d", bound, r.root.height)
+	}
+}
+
+func TestRopeSplitConcat(t *testing.T) {
+	text := strings.Repeat("0123456789", 500)
+	r := New(text)
+	left, right, ok := r.Split(1234)
+	if !ok || left.String() != text[:1234] || right.String() != text[1234:] {
+		t.Fatal("Expected Split to cut the text")
+	}
+	if r.String() != text {
+		t.Error("Expected Split to leave the rope unchanged")
+	}
+	right.Concat(left)
+	if right.String() != text[1234:]+text[:1234] {
+		t.Error("Expected Concat to append the text")
+	}
+	left.Insert(0, "x")
+	if !strings.HasSuffix(right.String(), text[:1234]) {
+		t.Error("Expected editing a concatenated rope to leave the other unchanged")
+	}
+	if _, _, ok := r.Split(len(text) + 1); ok {
+		t.Error("Expected Split past the end to fail")
+	}
+	checkInvariants(t, right.root)
+}
+
+func TestRopeConcurrentString(t *testing.T) {
+	text := strings.Repeat("abc", 1000)
+	r := New(text)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for n := len(text); n > 1; n-- {
+			r.Delete(n-1, n)
+		}
+	}()
+	for r.Length() > 1 {
+		if s := r.String(); s == "" || !strings.HasPrefix(text, s) {
+			t.Fatalf("Expected a non-empty prefix of the text, got %q", s)
+		}
+	}
+	wg.Wait()
+}
+
+func TestRopeLines(t *testing.T) {
+	r := New("first\nsecond line\n\nlast")
+	if r.LineCount() != 4 {
+		t.Fatalf("Expected 4 lines, got %d", r.LineCount())
+	}
+	expected := []string{"first", "second line", "", "last"}
+	starts := []int{0, 6, 18, 19}
+	for k := range expected {
+		if line, ok := r.Line(k); !ok || line != expected[k] {
+			t.Errorf("Expected line %d to be %q, got %q", k, expected[k], line)
+		}
+		if start, ok := r.LineStart(k); !ok || start != starts[k] {
+			t.Errorf("Expected line %d to start at %d, got %d", k, starts[k], start)
+		}
+		if line, ok := r.LineOf(starts[k]); !ok || line != k {
+			t.Errorf("Expected offset %d on line %d, got %d", starts[k], k, line)
+		}
+	}
+	if _, ok := r.Line(4); ok {
+		t.Error("Expected Line past the end to fail")
+	}
+	if line, _ := r.LineOf(r.Length()); line != 3 {
+		t.Errorf("Expected the end on the last line, got %d", line)
+	}
+
+	long := New(strings.Repeat("ab\n", 10000))
+	if line, ok := long.Line(7777); !ok || line != "ab" {
+		t.Errorf("Expected line ab, got %q", line)
+	}
+	if line, _ := long.LineOf(3*7777 + 1); line != 7777 {
+		t.Errorf("Expected line 7777, got %d", line)
+	}
+}
+
+func TestRopeReader(t *testing.T) {
+	text := strings.Repeat("héllo wörld\n", 1000)
+	r := New(text)
+	reader := r.Reader()
+	r.Delete(0, 100)
+	data, err := io.ReadAll(reader)
+	if err != nil || string(data) != text {
+		t.Fatalf("Expected the reader to return the text when it was created, got error %v", err)
+	}
+	if !utf8.Valid(data) {
+		t.Error("Expected valid UTF-8")
+	}
+	if err := iotest.TestReader(New(text).Reader(), []byte(text)); err != nil {
+		t.Error(err)
+	}
+	if n, err := New("").Reader().Read(make([]byte, 4)); n != 0 || err != io.EOF {
+		t.Errorf("Expected EOF from an empty rope, got %d, %v", n, err)
+	}
+}
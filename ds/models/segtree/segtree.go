@@ -0,0 +1,238 @@
+// Description: This package contains the implementation of a segment tree with lazy propagation.
+package segtree
+
+import "sync"
+
+// Monoid describes how the values of adjacent segments are aggregated.
+// Combine must be associative and Identity must be its neutral element.
+type Monoid[T any] struct {
+	Identity T
+	Combine  func(a, b T) T
+}
+
+// Lazy describes range updates of type U applied to aggregates of type T.
+type Lazy[T any, U any] struct {
+	// Apply returns the aggregate of a segment of the given length after the update.
+	Apply func(update U, aggregate T, length int) T
+	// Compose returns the update equivalent to applying older and then newer.
+	Compose func(newer, older U) U
+}
+
+// NoUpdate is the update type of trees that do not support range updates.
+type NoUpdate struct{}
+
+// The Tree struct represents a segment tree over a fixed number of values.
+// Ranges are half-open: [from, to) covers the indices from through to-1.
+type Tree[T any, U any] struct {
+	n       int
+	monoid  Monoid[T]
+	lazy    *Lazy[T, U]
+	tree    []T
+	updates []U
+	pending []bool
+	mu      sync.Mutex
+}
+
+func zeroValue[T any]() T {
+	var zero T
+	return zero
+}
+
+// Build creates a segment tree over a copy of values supporting point updates.
+func Build[T any](values []T, monoid Monoid[T]) *Tree[T, NoUpdate] {
+	return build[T, NoUpdate](values, monoid, nil)
+}
+
+// BuildLazy creates a segment tree over a copy of values supporting point and range updates.
+func BuildLazy[T any, U any](values []T, monoid Monoid[T], lazy Lazy[T, U]) *Tree[T, U] {
+	return build(values, monoid, &lazy)
+}
+
+func build[T any, U any](values []T, monoid Monoid[T], lazy *Lazy[T, U]) *Tree[T, U] {
+	t := &Tree[T, U]{
+		n:      len(values),
+		monoid: monoid,
+		lazy:   lazy,
+		tree:   make([]T, 4*len(values)),
+	}
+	if lazy != nil {
+		t.updates = make([]U, 4*len(values))
+		t.pending = make([]bool, 4*len(values))
+	}
+	if t.n > 0 {
+		t.build(1, 0, t.n, values)
+	}
+	return t
+}
+
+// build fills the node covering [l, r) from values.
+func (t *Tree[T, U]) build(node, l, r int, values []T) {
+	if r-l == 1 {
+		t.tree[node] = values[l]
+		return
+	}
+	m := (l + r) / 2
+	t.build(2*node, l, m, values)
+	t.build(2*node+1, m, r, values)
+	t.tree[node] = t.monoid.Combine(t.tree[2*node], t.tree[2*node+1])
+}
+
+// apply applies the update to the node covering [l, r) and defers it for its children.
+func (t *Tree[T, U]) apply(node, l, r int, update U) {
+	t.tree[node] = t.lazy.Apply(update, t.tree[node], r-l)
+	if r-l > 1 {
+		if t.pending[node] {
+			t.updates[node] = t.lazy.Compose(update, t.updates[node])
+		} else {
+			t.updates[node] = update
+			t.pending[node] = true
+		}
+	}
+}
+
+// push propagates the deferred update of the node covering [l, r) to its children.
+func (t *Tree[T, U]) push(node, l, r int) {
+	if t.lazy == nil || !t.pending[node] {
+		return
+	}
+	m := (l + r) / 2
+	t.apply(2*node, l, m, t.updates[node])
+	t.apply(2*node+1, m, r, t.updates[node])
+	t.updates[node] = zeroValue[U]()
+	t.pending[node] = false
+}
+
+// set replaces the value at index below the node covering [l, r).
+func (t *Tree[T, U]) set(node, l, r, index int, value T) {
+	if r-l == 1 {
+		t.tree[node] = value
+		return
+	}
+	t.push(node, l, r)
+	m := (l + r) / 2
+	if index < m {
+		t.set(2*node, l, m, index, value)
+	} else {
+		t.set(2*node+1, m, r, index, value)
+	}
+	t.tree[node] = t.monoid.Combine(t.tree[2*node], t.tree[2*node+1])
+}
+
+// update applies the update to [from, to) below the node covering [l, r).
+func (t *Tree[T, U]) update(node, l, r, from, to int, update U) {
+	if to <= l || r <= from {
+		return
+	}
+	if from <= l && r <= to {
+		t.apply(node, l, r, update)
+		return
+	}
+	t.push(node, l, r)
+	m := (l + r) / 2
+	t.update(2*node, l, m, from, to, update)
+	t.update(2*node+1, m, r, from, to, update)
+	t.tree[node] = t.monoid.Combine(t.tree[2*node], t.tree[2*node+1])
+}
+
+// query aggregates [from, to) below the node covering [l, r).
+func (t *Tree[T, U]) query(node, l, r, from, to int) T {
+	if to <= l || r <= from {
+		return t.monoid.Identity
+	}
+	if from <= l && r <= to {
+		return t.tree[node]
+	}
+	t.push(node, l, r)
+	m := (l + r) / 2
+	return t.monoid.Combine(t.query(2*node, l, m, from, to), t.query(2*node+1, m, r, from, to))
+}
+
+// findFirst returns the first index i >= from below the node covering [l, r) such that
+// pred holds for acc combined with the aggregate of [from, i], or -1.
+func (t *Tree[T, U]) findFirst(node, l, r, from int, acc *T, pred func(T) bool) int {
+	if r <= from {
+		return -1
+	}
+	if from <= l {
+		combined := t.monoid.Combine(*acc, t.tree[node])
+		if !pred(combined) {
+			*acc = combined
+			return -1
+		}
+		if r-l == 1 {
+			return l
+		}
+	}
+	t.push(node, l, r)
+	m := (l + r) / 2
+	if i := t.findFirst(2*node, l, m, from, acc, pred); i >= 0 {
+		return i
+	}
+	return t.findFirst(2*node+1, m, r, from, acc, pred)
+}
+
+// Length returns the number of values in the tree.
+func (t *Tree[T, U]) Length() int {
+	return t.n
+}
+
+// Get returns the value at the index in a concurrency-safe manner.
+func (t *Tree[T, U]) Get(index int) (T, bool) {
+	return t.Query(index, index+1)
+}
+
+// Set replaces the value at the index in a concurrency-safe manner.
+func (t *Tree[T, U]) Set(index int, value T) bool {
+	if index < 0 || index >= t.n {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.set(1, 0, t.n, index, value)
+	return true
+}
+
+// Update applies the update to every value in [from, to) in a concurrency-safe manner.
+// It returns false if the range is invalid or the tree was built without a lazy operator.
+func (t *Tree[T, U]) Update(from, to int, update U) bool {
+	if t.lazy == nil || from < 0 || to > t.n || from > to {
+		return false
+	}
+	if from == to {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update(1, 0, t.n, from, to, update)
+	return true
+}
+
+// Query returns the aggregate of the values in [from, to) in a concurrency-safe manner.
+// An empty range yields the identity of the monoid.
+func (t *Tree[T, U]) Query(from, to int) (T, bool) {
+	if from < 0 || to > t.n || from > to {
+		return zeroValue[T](), false
+	}
+	if from == to {
+		return t.monoid.Identity, true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.query(1, 0, t.n, from, to), true
+}
+
+// FindFirst returns the smallest index i >= from such that pred holds for the aggregate
+// of [from, i], in a concurrency-safe manner. pred must be monotone: once it holds for a
+// range starting at from it must hold for every longer one.
+func (t *Tree[T, U]) FindFirst(from int, pred func(T) bool) (int, bool) {
+	if from < 0 || from >= t.n {
+		return -1, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	acc := t.monoid.Identity
+	if i := t.findFirst(1, 0, t.n, from, &acc, pred); i >= 0 {
+		return i, true
+	}
+	return -1, false
+}
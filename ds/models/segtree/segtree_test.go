@@ -0,0 +1,142 @@
+package segtree_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/segtree"
+)
+
+var sum = segtree.Monoid[int]{
+	Identity: 0,
+	Combine:  func(a, b int) int { return a + b },
+}
+
+var minimum = segtree.Monoid[int]{
+	Identity: math.MaxInt,
+	Combine:  func(a, b int) int { return min(a, b) },
+}
+
+var add = segtree.Lazy[int, int]{
+	Apply:   func(update, aggregate, length int) int { return aggregate + update*length },
+	Compose: func(newer, older int) int { return newer + older },
+}
+
+var assign = segtree.Lazy[int, int]{
+	Apply:   func(update, aggregate, length int) int { return update },
+	Compose: func(newer, older int) int { return newer },
+}
+
+func TestSegmentTreePointUpdate(t *testing.T) {
+	tree := segtree.Build([]int{5, 3, 8, 1, 4}, minimum)
+	if value, _ := tree.Query(0, 5); value != 1 {
+		t.Errorf("Expected min 1, got %d", value)
+	}
+	tree.Set(3, 9)
+	if value, _ := tree.Query(0, 5); value != 3 {
+		t.Errorf("Expected min 3 after Set, got %d", value)
+	}
+	if value, _ := tree.Get(3); value != 9 {
+		t.Errorf("Expected Get to return 9, got %d", value)
+	}
+	if tree.Update(0, 2, segtree.NoUpdate{}) {
+		t.Error("Expected Update to fail without a lazy operator")
+	}
+}
+
+func TestSegmentTreeInvalidRanges(t *testing.T) {
+	tree := segtree.BuildLazy([]int{1, 2, 3}, sum, add)
+	tests := []struct {
+		name string
+		from int
+		to   int
+		ok   bool
+	}{
+		{name: "Whole range", from: 0, to: 3, ok: true},
+		{name: "Empty range", from: 1, to: 1, ok: true},
+		{name: "Negative start", from: -1, to: 2, ok: false},
+		{name: "End past length", from: 0, to: 4, ok: false},
+		{name: "Inverted range", from: 2, to: 1, ok: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, ok := tree.Query(test.from, test.to); ok != test.ok {
+				t.Errorf("Expected Query to return %t", test.ok)
+			}
+			if ok := tree.Update(test.from, test.to, 1); ok != test.ok {
+				t.Errorf("Expected Update to return %t", test.ok)
+			}
+		})
+	}
+	if tree.Set(3, 0) {
+		t.Error("Expected Set to fail past the end")
+	}
+}
+
+func TestSegmentTreeRandomized(t *testing.T) {
+	tests := []struct {
+		name   string
+		monoid segtree.Monoid[int]
+		lazy   segtree.Lazy[int, int]
+		apply  func(value, update int) int
+	}{
+		{name: "Range add, range sum", monoid: sum, lazy: add, apply: func(v, u int) int { return v + u }},
+		{name: "Range assign, range min", monoid: minimum, lazy: assign, apply: func(v, u int) int { return u }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			reference := make([]int, 100)
+			for i := range reference {
+				reference[i] = r.Intn(100)
+			}
+			tree := segtree.BuildLazy(reference, test.monoid, test.lazy)
+			for step := 0; step < 2000; step++ {
+				from := r.Intn(len(reference))
+				to := from + r.Intn(len(reference)-from) + 1
+				switch r.Intn(3) {
+				case 0:
+					value := r.Intn(100)
+					tree.Set(from, value)
+					reference[from] = value
+				case 1:
+					update := r.Intn(100) - 50
+					tree.Update(from, to, update)
+					for i := from; i < to; i++ {
+						reference[i] = test.apply(reference[i], update)
+					}
+				default:
+					expected := test.monoid.Identity
+					for i := from; i < to; i++ {
+						expected = test.monoid.Combine(expected, reference[i])
+					}
+					if got, _ := tree.Query(from, to); got != expected {
+						t.Fatalf("Query(%d, %d): expected %d, got %d", from, to, expected, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSegmentTreeFindFirst(t *testing.T) {
+	values := []int{2, 0, 3, 1, 4, 0, 5}
+	tree := segtree.BuildLazy(values, sum, add)
+	for from := range values {
+		for target := 0; target <= 16; target++ {
+			expected, acc := -1, 0
+			for i := from; i < len(values); i++ {
+				acc += values[i]
+				if acc >= target {
+					expected = i
+					break
+				}
+			}
+			got, ok := tree.FindFirst(from, func(s int) bool { return s >= target })
+			if got != expected || ok != (expected >= 0) {
+				t.Errorf("FindFirst(%d, >= %d): expected %d, got %d", from, target, expected, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,334 @@
+// Description: This package contains the implementation of a skip list ordered map with indexable access.
+package skiplist
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+)
+
+// MaxLevel is the largest number of levels of a skip list, enough for 4^32 entries.
+const MaxLevel = 32
+
+// Entry represents a single key-value pair stored in the list.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// level is a forward pointer of a node. Span is the number of entries the pointer skips
+// over, counting the one it points to; a nil pointer spans to the end of the list.
+type level[K any, V any] struct {
+	next *skipNode[K, V]
+	span int
+}
+
+// skipNode represents an entry and its forward pointers, one per level.
+type skipNode[K any, V any] struct {
+	entry  Entry[K, V]
+	levels []level[K, V]
+}
+
+// The SkipList struct represents an ordered map stored in a skip list. Each entry is
+// promoted to the next level with probability 1/4 using a seedable generator, so the
+// shape of a list is reproducible.
+type SkipList[K any, V any] struct {
+	head    *skipNode[K, V]
+	level   int
+	length  int
+	compare func(a, b K) int
+	rng     *rand.Rand
+	mu      sync.RWMutex
+}
+
+func zeroValue[T any]() T {
+	var zero T
+	return zero
+}
+
+// New creates a new skip list ordering keys naturally.
+func New[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return NewWithCompare[K, V](cmp.Compare[K])
+}
+
+// NewWithCompare creates a new skip list ordering keys with compare, which returns a
+// negative number, zero or a positive number when a < b, a == b or a > b.
+func NewWithCompare[K any, V any](compare func(a, b K) int) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		head:    &skipNode[K, V]{levels: make([]level[K, V], MaxLevel)},
+		level:   1,
+		compare: compare,
+		rng:     rand.New(rand.NewSource(1)),
+	}
+}
+
+// Seed resets the level generator in a concurrency-safe manner. Lists seeded alike and
+// given the same operations have the same shape.
+func (s *SkipList[K, V]) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rng.Seed(seed)
+}
+
+// randomLevel returns the number of levels of a new node.
+func (s *SkipList[K, V]) randomLevel() int {
+	n := 1
+	for n < MaxLevel && s.rng.Intn(4) == 0 {
+		n++
+	}
+	return n
+}
+
+// search returns, for every level, the last node whose key is less than the key and its
+// rank, the number of entries up to and including it.
+func (s *SkipList[K, V]) search(key K) ([MaxLevel]*skipNode[K, V], [MaxLevel]int) {
+	var update [MaxLevel]*skipNode[K, V]
+	var rank [MaxLevel]int
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		if i < s.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.levels[i].next != nil && s.compare(x.levels[i].next.entry.Key, key) < 0 {
+			rank[i] += x.levels[i].span
+			x = x.levels[i].next
+		}
+		update[i] = x
+	}
+	return update, rank
+}
+
+// put adds or replaces the value for the key. It returns true if the key was not present.
+func (s *SkipList[K, V]) put(key K, value V) bool {
+	update, rank := s.search(key)
+	if next := update[0].levels[0].next; next != nil && s.compare(next.entry.Key, key) == 0 {
+		next.entry.Value = value
+		return false
+	}
+	n := s.randomLevel()
+	for i := s.level; i < n; i++ {
+		update[i] = s.head
+		rank[i] = 0
+		s.head.levels[i].span = s.length
+	}
+	s.level = max(s.level, n)
+	x := &skipNode[K, V]{entry: Entry[K, V]{Key: key, Value: value}, levels: make([]level[K, V], n)}
+	for i := 0; i < n; i++ {
+		prev := &update[i].levels[i]
+		x.levels[i].next = prev.next
+		prev.next = x
+		x.levels[i].span = prev.span - (rank[0] - rank[i])
+		prev.span = rank[0] - rank[i] + 1
+	}
+	for i := n; i < s.level; i++ {
+		update[i].levels[i].span++
+	}
+	s.length++
+	return true
+}
+
+// remove removes the key and returns its entry.
+func (s *SkipList[K, V]) remove(key K) (Entry[K, V], bool) {
+	update, _ := s.search(key)
+	x := update[0].levels[0].next
+	if x == nil || s.compare(x.entry.Key, key) != 0 {
+		return Entry[K, V]{}, false
+	}
+	for i := 0; i < s.level; i++ {
+		prev := &update[i].levels[i]
+		if prev.next == x {
+			prev.span += x.levels[i].span - 1
+			prev.next = x.levels[i].next
+		} else {
+			prev.span--
+		}
+	}
+	for s.level > 1 && s.head.levels[s.level-1].next == nil {
+		s.head.levels[s.level-1].span = 0
+		s.level--
+	}
+	s.length--
+	return x.entry, true
+}
+
+// floor returns the node with the largest key less than or equal to the key, or nil.
+func (s *SkipList[K, V]) floor(key K) *skipNode[K, V] {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && s.compare(x.levels[i].next.entry.Key, key) <= 0 {
+			x = x.levels[i].next
+		}
+	}
+	if x == s.head {
+		return nil
+	}
+	return x
+}
+
+// ceiling returns the node with the smallest key greater than or equal to the key, or nil.
+func (s *SkipList[K, V]) ceiling(key K) *skipNode[K, V] {
+	update, _ := s.search(key)
+	return update[0].levels[0].next
+}
+
+// byRank returns the node with the given number of smaller keys, or nil.
+func (s *SkipList[K, V]) byRank(rank int) *skipNode[K, V] {
+	if rank < 0 || rank >= s.length {
+		return nil
+	}
+	target := rank + 1
+	traversed := 0
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.levels[i].next != nil && traversed+x.levels[i].span <= target {
+			traversed += x.levels[i].span
+			x = x.levels[i].next
+		}
+		if traversed == target {
+			return x
+		}
+	}
+	return nil
+}
+
+// Put adds or replaces the value for the key in a concurrency-safe manner.
+// It returns true if the key was not already present.
+func (s *SkipList[K, V]) Put(key K, value V) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.put(key, value)
+}
+
+// Get returns the value for the key in a concurrency-safe manner.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if x := s.ceiling(key); x != nil && s.compare(x.entry.Key, key) == 0 {
+		return x.entry.Value, true
+	}
+	return zeroValue[V](), false
+}
+
+// Has returns true if the key is present in a concurrency-safe manner.
+func (s *SkipList[K, V]) Has(key K) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// Delete removes the key in a concurrency-safe manner.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.remove(key)
+	return ok
+}
+
+// entryOf returns the key and value of the node, or false if it is nil.
+func entryOf[K any, V any](x *skipNode[K, V]) (K, V, bool) {
+	if x == nil {
+		return zeroValue[K](), zeroValue[V](), false
+	}
+	return x.entry.Key, x.entry.Value, true
+}
+
+// Floor returns the entry with the largest key less than or equal to the key in a concurrency-safe manner.
+func (s *SkipList[K, V]) Floor(key K) (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return entryOf(s.floor(key))
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to the key in a concurrency-safe manner.
+func (s *SkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return entryOf(s.ceiling(key))
+}
+
+// Min returns the entry with the smallest key in a concurrency-safe manner.
+func (s *SkipList[K, V]) Min() (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return entryOf(s.head.levels[0].next)
+}
+
+// Max returns the entry with the largest key in a concurrency-safe manner.
+func (s *SkipList[K, V]) Max() (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return entryOf(s.byRank(s.length - 1))
+}
+
+// GetByRank returns the entry with rank smaller keys, counting from zero, in O(log n)
+// in a concurrency-safe manner.
+func (s *SkipList[K, V]) GetByRank(rank int) (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return entryOf(s.byRank(rank))
+}
+
+// Rank returns the number of keys less than the key in O(log n) in a concurrency-safe manner,
+// and whether the key is present.
+func (s *SkipList[K, V]) Rank(key K) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	update, rank := s.search(key)
+	next := update[0].levels[0].next
+	return rank[0], next != nil && s.compare(next.entry.Key, key) == 0
+}
+
+// Length returns the number of entries in the list in a concurrency-safe manner.
+func (s *SkipList[K, V]) Length() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.length
+}
+
+// IsEmpty returns true if the list holds no entries in a concurrency-safe manner.
+func (s *SkipList[K, V]) IsEmpty() bool {
+	return s.Length() == 0
+}
+
+// iterate sends the entries from start while keep returns true to a channel, holding the read lock.
+func (s *SkipList[K, V]) iterate(start func() *skipNode[K, V], keep func(key K) bool) <-chan Entry[K, V] {
+	s.mu.RLock()
+	ch := make(chan Entry[K, V])
+	go func() {
+		defer s.mu.RUnlock()
+		for x := start(); x != nil && keep(x.entry.Key); x = x.levels[0].next {
+			ch <- x.entry
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Range returns a channel that iterates over all entries in ascending key order in a concurrency-safe manner.
+func (s *SkipList[K, V]) Range() <-chan Entry[K, V] {
+	return s.iterate(
+		func() *skipNode[K, V] { return s.head.levels[0].next },
+		func(K) bool { return true },
+	)
+}
+
+// AscendRange returns a channel that iterates over the entries with from <= key < to
+// in ascending key order in a concurrency-safe manner.
+func (s *SkipList[K, V]) AscendRange(from, to K) <-chan Entry[K, V] {
+	return s.iterate(
+		func() *skipNode[K, V] { return s.ceiling(from) },
+		func(key K) bool { return s.compare(key, to) < 0 },
+	)
+}
+
+// RangeByRank returns a channel that iterates over the entries whose ranks are in [from, to)
+// in ascending key order in a concurrency-safe manner.
+func (s *SkipList[K, V]) RangeByRank(from, to int) <-chan Entry[K, V] {
+	remaining := to - max(from, 0)
+	return s.iterate(
+		func() *skipNode[K, V] { return s.byRank(max(from, 0)) },
+		func(K) bool {
+			remaining--
+			return remaining >= 0
+		},
+	)
+}
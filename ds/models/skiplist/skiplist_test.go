@@ -0,0 +1,163 @@
+package skiplist
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// collect drains a channel of entries into their keys.
+func collect[K any, V any](ch <-chan Entry[K, V]) []K {
+	var out []K
+	for entry := range ch {
+		out = append(out, entry.Key)
+	}
+	return out
+}
+
+// checkSpans verifies that every forward pointer spans the number of entries it skips.
+func checkSpans[K any, V any](t *testing.T, s *SkipList[K, V]) {
+	t.Helper()
+	position := map[*skipNode[K, V]]int{s.head: 0}
+	i := 1
+	for x := s.head.levels[0].next; x != nil; x = x.levels[0].next {
+		position[x] = i
+		i++
+	}
+	for x := s.head; x != nil; x = x.levels[0].next {
+		for l := 0; l < min(len(x.levels), s.level); l++ {
+			// A nil pointer spans to the end of the list.
+			end := s.length
+			if next := x.levels[l].next; next != nil {
+				end = position[next]
+			}
+			if x.levels[l].span != end-position[x] {
+				t.Fatalf("Expected span %d at level %d, got %d", end-position[x], l, x.levels[l].span)
+			}
+		}
+	}
+}
+
+func TestSkipListRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := New[int, int]()
+	reference := make(map[int]int)
+	for n := 0; n < 20000; n++ {
+		key := rng.Intn(2000)
+		if rng.Intn(3) == 0 {
+			_, present := reference[key]
+			if s.Delete(key) != present {
+				t.Fatalf("Unexpected result deleting %d", key)
+			}
+			delete(reference, key)
+		} else {
+			_, present := reference[key]
+			if s.Put(key, n) == present {
+				t.Fatalf("Unexpected result putting %d", key)
+			}
+			reference[key] = n
+		}
+		if n%2000 == 0 {
+			checkSpans(t, s)
+		}
+	}
+	checkSpans(t, s)
+
+	expected := make([]int, 0, len(reference))
+	for key := range reference {
+		expected = append(expected, key)
+	}
+	slices.Sort(expected)
+	if s.Length() != len(expected) {
+		t.Fatalf("Expected length %d, got %d", len(expected), s.Length())
+	}
+	if keys := collect(s.Range()); !slices.Equal(keys, expected) {
+		t.Fatal("Expected Range to return the keys in order")
+	}
+	for rank, key := range expected {
+		if k, v, ok := s.GetByRank(rank); !ok || k != key || v != reference[key] {
+			t.Fatalf("Expected rank %d to hold %d, got %d", rank, key, k)
+		}
+		if r, ok := s.Rank(key); !ok || r != rank {
+			t.Fatalf("Expected key %d at rank %d, got %d", key, rank, r)
+		}
+	}
+	if _, _, ok := s.GetByRank(len(expected)); ok {
+		t.Error("Expected GetByRank past the end to fail")
+	}
+	if r, ok := s.Rank(5000); ok || r != len(expected) {
+		t.Errorf("Expected absent key to rank last, got %d", r)
+	}
+}
+
+func TestSkipListFloorCeiling(t *testing.T) {
+	s := New[int, string]()
+	for _, key := range []int{10, 20, 30} {
+		s.Put(key, "v")
+	}
+	tests := []struct {
+		key            int
+		floor, ceiling int
+		hasFloor       bool
+		hasCeiling     bool
+	}{
+		{key: 5, ceiling: 10, hasCeiling: true},
+		{key: 10, floor: 10, ceiling: 10, hasFloor: true, hasCeiling: true},
+		{key: 25, floor: 20, ceiling: 30, hasFloor: true, hasCeiling: true},
+		{key: 35, floor: 30, hasFloor: true},
+	}
+	for _, test := range tests {
+		if k, _, ok := s.Floor(test.key); ok != test.hasFloor || (ok && k != test.floor) {
+			t.Errorf("Expected Floor(%d) = %d, %t, got %d, %t", test.key, test.floor, test.hasFloor, k, ok)
+		}
+		if k, _, ok := s.Ceiling(test.key); ok != test.hasCeiling || (ok && k != test.ceiling) {
+			t.Errorf("Expected Ceiling(%d) = %d, %t, got %d, %t", test.key, test.ceiling, test.hasCeiling, k, ok)
+		}
+	}
+	if k, _, _ := s.Min(); k != 10 {
+		t.Errorf("Expected min 10, got %d", k)
+	}
+	if k, _, _ := s.Max(); k != 30 {
+		t.Errorf("Expected max 30, got %d", k)
+	}
+}
+
+func TestSkipListRanges(t *testing.T) {
+	s := New[int, int]()
+	for i := 0; i < 100; i++ {
+		s.Put(i*2, i)
+	}
+	if keys := collect(s.AscendRange(11, 21)); !slices.Equal(keys, []int{12, 14, 16, 18, 20}) {
+		t.Errorf("Expected keys in [11, 21), got %v", keys)
+	}
+	if keys := collect(s.RangeByRank(97, 105)); !slices.Equal(keys, []int{194, 196, 198}) {
+		t.Errorf("Expected the last three keys, got %v", keys)
+	}
+	if keys := collect(s.AscendRange(300, 400)); len(keys) != 0 {
+		t.Errorf("Expected no keys, got %v", keys)
+	}
+}
+
+func TestSkipListSeed(t *testing.T) {
+	shape := func(seed int64) []int {
+		s := NewWithCompare[string, int](func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+		s.Seed(seed)
+		for i := 0; i < 200; i++ {
+			s.Put(string(rune('a'+i%26))+string(rune('A'+i/26)), i)
+		}
+		var heights []int
+		for x := s.head.levels[0].next; x != nil; x = x.levels[0].next {
+			heights = append(heights, len(x.levels))
+		}
+		return heights
+	}
+	if !slices.Equal(shape(42), shape(42)) {
+		t.Error("Expected lists with the same seed to have the same shape")
+	}
+	if slices.Equal(shape(42), shape(43)) {
+		t.Error("Expected lists with different seeds to differ")
+	}
+}
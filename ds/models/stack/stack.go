@@ -1,13 +1,10 @@
 // Description: This package contains the implementation of stack data structure.
 package stack
 
-import (
-	"github.com/mmygods/gods/ds/collections"
-	"github.com/mmygods/gods/ds/models/dll"
-)
+import "github.com/mmygods/gods/ds/models/dll"
 
 type Stack[T any] struct {
-	data collections.List[T]
+	data *dll.DoublyLinkedList[T]
 }
 
 func zeroValue[T any]() T {
@@ -41,6 +38,25 @@ func (s *Stack[T]) Peek() (T, bool) {
 	return s.data.Get(s.data.Length() - 1)
 }
 
+// Clear removes every element from the stack.
+func (s *Stack[T]) Clear() {
+	s.data.Clear()
+}
+
+// Observe registers fn to be called synchronously with every change of the stack and returns
+// a function that unregisters it. Indices count from the bottom of the stack, so a push is
+// inserted and a pop removed at Length()-1. fn must not call methods of the stack.
+func (s *Stack[T]) Observe(fn func(dll.Event[T])) (cancel func()) {
+	return s.data.Observe(fn)
+}
+
+// Subscribe registers a subscription receiving every change of the stack asynchronously,
+// queueing at most buffer undelivered events and dropping the oldest beyond that. Indices
+// count from the bottom of the stack.
+func (s *Stack[T]) Subscribe(buffer int) *dll.Subscription[T] {
+	return s.data.Subscribe(buffer)
+}
+
 // IsEmpty returns true if the stack is empty, false otherwise.
 func (s *Stack[T]) IsEmpty() bool {
 	return s.data.IsEmpty()
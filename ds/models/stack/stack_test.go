@@ -1,9 +1,12 @@
 package stack_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/mmygods/gods/ds/collections"
+	"github.com/mmygods/gods/ds/collections/collectionstest"
+	"github.com/mmygods/gods/ds/models/dll"
 	"github.com/mmygods/gods/ds/models/stack"
 )
 
@@ -133,3 +136,41 @@ func TestStackInterface(t *testing.T) {
 		})
 	}
 }
+
+func TestStackObserve(t *testing.T) {
+	s := stack.New[int]()
+	var events []dll.Event[int]
+	s.Observe(func(e dll.Event[int]) {
+		events = append(events, e)
+	})
+	sub := s.Subscribe(4)
+	defer sub.Unsubscribe()
+	s.Push(1)
+	s.Push(2)
+	s.Pop()
+	s.Clear()
+
+	expected := []dll.Event[int]{
+		{Kind: dll.Inserted, Index: 0, Value: 1},
+		{Kind: dll.Inserted, Index: 1, Value: 2},
+		{Kind: dll.Removed, Index: 1, Value: 2},
+		{Kind: dll.Cleared},
+	}
+	if !slices.Equal(events, expected) {
+		t.Errorf("Expected events %v, got %v", expected, events)
+	}
+	for _, e := range expected {
+		if received := <-sub.Events(); received != e {
+			t.Errorf("Expected %v from the subscription, got %v", e, received)
+		}
+	}
+	if !s.IsEmpty() {
+		t.Error("Expected stack to be empty after Clear")
+	}
+}
+
+func TestStackConformance(t *testing.T) {
+	collectionstest.TestStack(t, func() collections.Stack[int] {
+		return stack.New[int]()
+	})
+}
@@ -0,0 +1,267 @@
+// Description: This package contains the implementation of a trie keyed by strings.
+package trie
+
+import (
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// Entry represents a single key-value pair stored in the trie.
+type Entry[V any] struct {
+	Key   string
+	Value V
+}
+
+// trieNode represents a node in the trie. Children are kept sorted by rune.
+type trieNode[V any] struct {
+	children []*trieNode[V]
+	r        rune
+	value    V
+	hasValue bool
+}
+
+// The Trie struct represents a trie mapping string keys to values, one rune per level.
+// Keys are expected to be valid UTF-8.
+type Trie[V any] struct {
+	root   trieNode[V]
+	length int
+	mu     sync.RWMutex
+}
+
+func zeroValue[T any]() T {
+	var zero T
+	return zero
+}
+
+// New creates a new trie.
+func New[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// childIndex returns the position of the child for r, and whether it exists.
+func (n *trieNode[V]) childIndex(r rune) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].r >= r
+	})
+	return i, i < len(n.children) && n.children[i].r == r
+}
+
+// child returns the child for r, or nil.
+func (n *trieNode[V]) child(r rune) *trieNode[V] {
+	if i, ok := n.childIndex(r); ok {
+		return n.children[i]
+	}
+	return nil
+}
+
+// addChild returns the child for r, creating it if necessary.
+func (n *trieNode[V]) addChild(r rune) *trieNode[V] {
+	i, ok := n.childIndex(r)
+	if ok {
+		return n.children[i]
+	}
+	child := &trieNode[V]{r: r}
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+	return child
+}
+
+// removeChild removes the child for r and releases unused capacity.
+func (n *trieNode[V]) removeChild(r rune) {
+	i, ok := n.childIndex(r)
+	if !ok {
+		return
+	}
+	copy(n.children[i:], n.children[i+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+	if len(n.children) == 0 {
+		n.children = nil
+	} else if cap(n.children) > 2*len(n.children) {
+		n.children = append([]*trieNode[V](nil), n.children...)
+	}
+}
+
+// find returns the node reached by following key, or nil.
+func (t *Trie[V]) find(key string) *trieNode[V] {
+	node := &t.root
+	for _, r := range key {
+		node = node.child(r)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// put associates the value with the key. It returns true if the key was not present.
+func (t *Trie[V]) put(key string, value V) bool {
+	node := &t.root
+	for _, r := range key {
+		node = node.addChild(r)
+	}
+	added := !node.hasValue
+	node.value = value
+	node.hasValue = true
+	if added {
+		t.length++
+	}
+	return added
+}
+
+// get returns the value associated with the key.
+func (t *Trie[V]) get(key string) (V, bool) {
+	node := t.find(key)
+	if node == nil || !node.hasValue {
+		return zeroValue[V](), false
+	}
+	return node.value, true
+}
+
+// delete removes the key and prunes nodes that no longer lead to a value.
+func (t *Trie[V]) delete(key string) bool {
+	path := []*trieNode[V]{&t.root}
+	node := &t.root
+	for _, r := range key {
+		node = node.child(r)
+		if node == nil {
+			return false
+		}
+		path = append(path, node)
+	}
+	if !node.hasValue {
+		return false
+	}
+	node.value = zeroValue[V]()
+	node.hasValue = false
+	t.length--
+	for i := len(path) - 1; i > 0; i-- {
+		current := path[i]
+		if current.hasValue || len(current.children) > 0 {
+			break
+		}
+		path[i-1].removeChild(current.r)
+	}
+	return true
+}
+
+// longestPrefixOf returns the longest key in the trie that is a prefix of s.
+func (t *Trie[V]) longestPrefixOf(s string) (string, V, bool) {
+	node := &t.root
+	end, found := 0, node.hasValue
+	value := node.value
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		node = node.child(r)
+		if node == nil {
+			break
+		}
+		if node.hasValue {
+			end = i
+			value = node.value
+			found = true
+		}
+	}
+	if !found {
+		return "", zeroValue[V](), false
+	}
+	return s[:end], value, true
+}
+
+// walk sends every entry below node to ch in lexicographic order.
+func walk[V any](node *trieNode[V], prefix []rune, ch chan<- Entry[V]) {
+	if node.hasValue {
+		ch <- Entry[V]{Key: string(prefix), Value: node.value}
+	}
+	for _, child := range node.children {
+		walk(child, append(prefix, child.r), ch)
+	}
+}
+
+// Put associates the value with the key in a concurrency-safe manner.
+// It returns true if the key was not already present.
+func (t *Trie[V]) Put(key string, value V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.put(key, value)
+}
+
+// Get returns the value associated with the key in a concurrency-safe manner.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.get(key)
+}
+
+// Delete removes the key in a concurrency-safe manner.
+func (t *Trie[V]) Delete(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.delete(key)
+}
+
+// HasPrefix returns true if any key starts with the prefix in a concurrency-safe manner.
+func (t *Trie[V]) HasPrefix(prefix string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	node := t.find(prefix)
+	return node != nil && (node.hasValue || len(node.children) > 0)
+}
+
+// LongestPrefixOf returns the longest key that is a prefix of s, with its value, in a concurrency-safe manner.
+func (t *Trie[V]) LongestPrefixOf(s string) (string, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.longestPrefixOf(s)
+}
+
+// Length returns the number of keys in the trie in a concurrency-safe manner.
+func (t *Trie[V]) Length() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.length
+}
+
+// IsEmpty returns true if the trie holds no keys in a concurrency-safe manner.
+func (t *Trie[V]) IsEmpty() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.length == 0
+}
+
+// RangePrefix returns a channel that iterates over the entries whose keys start with the prefix,
+// in lexicographic order, in a concurrency-safe manner.
+func (t *Trie[V]) RangePrefix(prefix string) <-chan Entry[V] {
+	t.mu.RLock()
+	ch := make(chan Entry[V])
+	go func() {
+		defer t.mu.RUnlock()
+		if node := t.find(prefix); node != nil {
+			walk(node, []rune(prefix), ch)
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// KeysWithPrefix returns a channel that iterates over the keys starting with the prefix,
+// in lexicographic order, in a concurrency-safe manner.
+func (t *Trie[V]) KeysWithPrefix(prefix string) <-chan string {
+	entries := t.RangePrefix(prefix)
+	ch := make(chan string)
+	go func() {
+		for entry := range entries {
+			ch <- entry.Key
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Range returns a channel that iterates over all entries in lexicographic order in a concurrency-safe manner.
+func (t *Trie[V]) Range() <-chan Entry[V] {
+	return t.RangePrefix("")
+}
@@ -0,0 +1,146 @@
+package trie_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/trie"
+)
+
+func collect(ch <-chan string) []string {
+	var keys []string
+	for key := range ch {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTriePutGetDelete(t *testing.T) {
+	tr := trie.New[int]()
+	if !tr.Put("car", 1) || !tr.Put("cart", 2) || !tr.Put("日本", 3) {
+		t.Error("Expected Put to return true for new keys")
+	}
+	if tr.Put("car", 4) {
+		t.Error("Expected Put to return false for existing key")
+	}
+	if value, ok := tr.Get("car"); !ok || value != 4 {
+		t.Errorf("Expected 4, got %d", value)
+	}
+	if value, ok := tr.Get("日本"); !ok || value != 3 {
+		t.Errorf("Expected 3, got %d", value)
+	}
+	if _, ok := tr.Get("ca"); ok {
+		t.Error("Expected intermediate node not to hold a value")
+	}
+	if tr.Length() != 3 {
+		t.Errorf("Expected length 3, got %d", tr.Length())
+	}
+	if tr.Delete("ca") {
+		t.Error("Expected Delete to return false for missing key")
+	}
+	if !tr.Delete("cart") {
+		t.Error("Expected Delete to return true for existing key")
+	}
+	if tr.HasPrefix("cart") {
+		t.Error("Expected deleted branch to be pruned")
+	}
+	if !tr.HasPrefix("ca") {
+		t.Error("Expected remaining key to keep its prefix")
+	}
+	tr.Delete("car")
+	tr.Delete("日本")
+	if !tr.IsEmpty() || tr.HasPrefix("") {
+		t.Error("Expected trie to be empty")
+	}
+}
+
+func TestTrieKeysWithPrefix(t *testing.T) {
+	tr := trie.New[bool]()
+	for _, key := range []string{"banana", "band", "apple", "ban", "bandana", "b", "日"} {
+		tr.Put(key, true)
+	}
+	tests := []struct {
+		name     string
+		prefix   string
+		expected []string
+	}{
+		{
+			name:     "All keys",
+			prefix:   "",
+			expected: []string{"apple", "b", "ban", "banana", "band", "bandana", "日"},
+		},
+		{
+			name:     "Shared prefix",
+			prefix:   "ban",
+			expected: []string{"ban", "banana", "band", "bandana"},
+		},
+		{
+			name:     "Missing prefix",
+			prefix:   "c",
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if keys := collect(tr.KeysWithPrefix(test.prefix)); !equal(keys, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, keys)
+			}
+		})
+	}
+}
+
+func TestTrieLongestPrefixOf(t *testing.T) {
+	tr := trie.New[string]()
+	tr.Put("/", "root")
+	tr.Put("/api", "api")
+	tr.Put("/api/v1", "v1")
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{input: "/api/v1/users", expected: "/api/v1", ok: true},
+		{input: "/api/v2", expected: "/api", ok: true},
+		{input: "/static", expected: "/", ok: true},
+		{input: "api", expected: "", ok: false},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			key, _, ok := tr.LongestPrefixOf(test.input)
+			if ok != test.ok || key != test.expected {
+				t.Errorf("Expected (%q, %t), got (%q, %t)", test.expected, test.ok, key, ok)
+			}
+		})
+	}
+}
+
+func TestTrieConcurrency(t *testing.T) {
+	tr := trie.New[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := string(rune('a'+i%26)) + string(rune('a'+i/26))
+			tr.Put(key, i)
+			tr.Get(key)
+			tr.HasPrefix(key[:1])
+		}(i)
+	}
+	wg.Wait()
+	if tr.Length() != 100 {
+		t.Errorf("Expected length 100, got %d", tr.Length())
+	}
+}
@@ -0,0 +1,374 @@
+// Description: This package contains the implementation of disjoint-set union-find structures.
+package unionfind
+
+import "sync"
+
+// change records a single mutation of a forest so that it can be rolled back.
+// A change with child set to -1 records the addition of an element.
+type change struct {
+	child         int
+	root          int
+	rankIncreased bool
+}
+
+// forest implements union by rank over the elements 0 through len(parent)-1.
+// It is not safe for concurrent use; callers hold their own lock.
+type forest struct {
+	parent   []int
+	rank     []int
+	size     []int
+	sets     int
+	rollback bool
+	history  []change
+}
+
+func newForest(n int, rollback bool) forest {
+	f := forest{rollback: rollback}
+	for i := 0; i < n; i++ {
+		f.add()
+	}
+	f.history = nil
+	return f
+}
+
+// add adds a new singleton set and returns its element.
+func (f *forest) add() int {
+	x := len(f.parent)
+	f.parent = append(f.parent, x)
+	f.rank = append(f.rank, 0)
+	f.size = append(f.size, 1)
+	f.sets++
+	if f.rollback {
+		f.history = append(f.history, change{child: -1})
+	}
+	return x
+}
+
+// find returns the root of x, compressing the path unless rollback is enabled.
+func (f *forest) find(x int) int {
+	root := x
+	for f.parent[root] != root {
+		root = f.parent[root]
+	}
+	if !f.rollback {
+		for f.parent[x] != root {
+			x, f.parent[x] = f.parent[x], root
+		}
+	}
+	return root
+}
+
+// union merges the sets of a and b. It returns false if they were already joined.
+func (f *forest) union(a, b int) bool {
+	a, b = f.find(a), f.find(b)
+	if a == b {
+		return false
+	}
+	if f.rank[a] < f.rank[b] {
+		a, b = b, a
+	}
+	f.parent[b] = a
+	f.size[a] += f.size[b]
+	increased := f.rank[a] == f.rank[b]
+	if increased {
+		f.rank[a]++
+	}
+	f.sets--
+	if f.rollback {
+		f.history = append(f.history, change{child: b, root: a, rankIncreased: increased})
+	}
+	return true
+}
+
+// undo reverts mutations until the history has the given length.
+func (f *forest) undo(length int) bool {
+	if !f.rollback || length < 0 || length > len(f.history) {
+		return false
+	}
+	for len(f.history) > length {
+		c := f.history[len(f.history)-1]
+		f.history = f.history[:len(f.history)-1]
+		if c.child < 0 {
+			last := len(f.parent) - 1
+			f.parent = f.parent[:last]
+			f.rank = f.rank[:last]
+			f.size = f.size[:last]
+			f.sets--
+			continue
+		}
+		f.parent[c.child] = c.child
+		f.size[c.root] -= f.size[c.child]
+		if c.rankIncreased {
+			f.rank[c.root]--
+		}
+		f.sets++
+	}
+	return true
+}
+
+// groups returns the elements of every set, keyed by root.
+func (f *forest) groups() map[int][]int {
+	groups := make(map[int][]int, f.sets)
+	for x := range f.parent {
+		root := f.find(x)
+		groups[root] = append(groups[root], x)
+	}
+	return groups
+}
+
+// The Dense struct represents a union-find over the integers 0 through n-1.
+type Dense struct {
+	forest forest
+	mu     sync.Mutex
+}
+
+// NewDense creates a new union-find of n singleton sets using path compression.
+func NewDense(n int) *Dense {
+	return &Dense{forest: newForest(n, false)}
+}
+
+// NewDenseRollback creates a new union-find of n singleton sets supporting Snapshot and Rollback.
+// Path compression is disabled so that every union can be undone; Find is O(log n).
+func NewDenseRollback(n int) *Dense {
+	return &Dense{forest: newForest(n, true)}
+}
+
+// valid reports whether x is an element of the union-find.
+func (d *Dense) valid(x int) bool {
+	return x >= 0 && x < len(d.forest.parent)
+}
+
+// Add adds a new singleton set in a concurrency-safe manner and returns its element.
+func (d *Dense) Add() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.forest.add()
+}
+
+// Find returns the representative of the set containing x in a concurrency-safe manner.
+func (d *Dense) Find(x int) (int, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.valid(x) {
+		return -1, false
+	}
+	return d.forest.find(x), true
+}
+
+// Union merges the sets containing a and b in a concurrency-safe manner.
+// It returns false if either element is invalid or both are already in the same set.
+func (d *Dense) Union(a, b int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.valid(a) || !d.valid(b) {
+		return false
+	}
+	return d.forest.union(a, b)
+}
+
+// Connected returns true if a and b are in the same set in a concurrency-safe manner.
+func (d *Dense) Connected(a, b int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.valid(a) || !d.valid(b) {
+		return false
+	}
+	return d.forest.find(a) == d.forest.find(b)
+}
+
+// SetSize returns the number of elements in the set containing x in a concurrency-safe manner.
+func (d *Dense) SetSize(x int) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.valid(x) {
+		return 0
+	}
+	return d.forest.size[d.forest.find(x)]
+}
+
+// SetCount returns the number of disjoint sets in a concurrency-safe manner.
+func (d *Dense) SetCount() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.forest.sets
+}
+
+// Length returns the number of elements in a concurrency-safe manner.
+func (d *Dense) Length() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.forest.parent)
+}
+
+// Groups returns the elements of every set in a concurrency-safe manner.
+// Elements within a group are in ascending order; the order of groups is unspecified.
+func (d *Dense) Groups() [][]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	groups := make([][]int, 0, d.forest.sets)
+	for _, group := range d.forest.groups() {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// Snapshot returns a token recording the current state in a concurrency-safe manner.
+func (d *Dense) Snapshot() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.forest.history)
+}
+
+// Rollback restores the state recorded by Snapshot in a concurrency-safe manner, undoing
+// later unions and additions. It returns false if rollback is not enabled or the token
+// is no longer valid.
+func (d *Dense) Rollback(snapshot int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.forest.undo(snapshot)
+}
+
+// The UnionFind struct represents a union-find over arbitrary comparable elements.
+// Elements are added on first use by Add or Union.
+type UnionFind[T comparable] struct {
+	ids    map[T]int
+	items  []T
+	forest forest
+	mu     sync.Mutex
+}
+
+// New creates a new empty union-find using path compression.
+func New[T comparable]() *UnionFind[T] {
+	return &UnionFind[T]{ids: make(map[T]int)}
+}
+
+// NewRollback creates a new empty union-find supporting Snapshot and Rollback.
+// Path compression is disabled so that every union can be undone; Find is O(log n).
+func NewRollback[T comparable]() *UnionFind[T] {
+	return &UnionFind[T]{ids: make(map[T]int), forest: newForest(0, true)}
+}
+
+// id returns the element id of x, adding x as a singleton set if necessary.
+func (u *UnionFind[T]) id(x T) int {
+	if id, ok := u.ids[x]; ok {
+		return id
+	}
+	id := u.forest.add()
+	u.ids[x] = id
+	u.items = append(u.items, x)
+	return id
+}
+
+// Add adds x as a singleton set in a concurrency-safe manner.
+// It returns false if x is already present.
+func (u *UnionFind[T]) Add(x T) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, ok := u.ids[x]; ok {
+		return false
+	}
+	u.id(x)
+	return true
+}
+
+// Contains returns true if x has been added in a concurrency-safe manner.
+func (u *UnionFind[T]) Contains(x T) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	_, ok := u.ids[x]
+	return ok
+}
+
+// Find returns the representative of the set containing x in a concurrency-safe manner.
+func (u *UnionFind[T]) Find(x T) (T, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	id, ok := u.ids[x]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return u.items[u.forest.find(id)], true
+}
+
+// Union merges the sets containing a and b in a concurrency-safe manner, adding either
+// element if necessary. It returns false if both are already in the same set.
+func (u *UnionFind[T]) Union(a, b T) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.forest.union(u.id(a), u.id(b))
+}
+
+// Connected returns true if a and b are in the same set in a concurrency-safe manner.
+func (u *UnionFind[T]) Connected(a, b T) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	idA, okA := u.ids[a]
+	idB, okB := u.ids[b]
+	return okA && okB && u.forest.find(idA) == u.forest.find(idB)
+}
+
+// SetSize returns the number of elements in the set containing x in a concurrency-safe manner.
+func (u *UnionFind[T]) SetSize(x T) int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	id, ok := u.ids[x]
+	if !ok {
+		return 0
+	}
+	return u.forest.size[u.forest.find(id)]
+}
+
+// SetCount returns the number of disjoint sets in a concurrency-safe manner.
+func (u *UnionFind[T]) SetCount() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.forest.sets
+}
+
+// Length returns the number of elements in a concurrency-safe manner.
+func (u *UnionFind[T]) Length() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return len(u.items)
+}
+
+// Groups returns the elements of every set in a concurrency-safe manner.
+// Elements within a group are in insertion order; the order of groups is unspecified.
+func (u *UnionFind[T]) Groups() [][]T {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	groups := make([][]T, 0, u.forest.sets)
+	for _, ids := range u.forest.groups() {
+		group := make([]T, len(ids))
+		for i, id := range ids {
+			group[i] = u.items[id]
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// Snapshot returns a token recording the current state in a concurrency-safe manner.
+func (u *UnionFind[T]) Snapshot() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return len(u.forest.history)
+}
+
+// Rollback restores the state recorded by Snapshot in a concurrency-safe manner, undoing
+// later unions and removing elements added since. It returns false if rollback is not
+// enabled or the token is no longer valid.
+func (u *UnionFind[T]) Rollback(snapshot int) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.forest.undo(snapshot) {
+		return false
+	}
+	for _, x := range u.items[len(u.forest.parent):] {
+		delete(u.ids, x)
+	}
+	clear(u.items[len(u.forest.parent):])
+	u.items = u.items[:len(u.forest.parent)]
+	return true
+}
@@ -0,0 +1,131 @@
+package unionfind_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mmygods/gods/ds/models/unionfind"
+)
+
+func TestDenseUnionFind(t *testing.T) {
+	d := unionfind.NewDense(6)
+	if !d.Union(0, 1) || !d.Union(2, 3) || !d.Union(1, 3) {
+		t.Error("Expected unions of disjoint sets to return true")
+	}
+	if d.Union(0, 2) {
+		t.Error("Expected union within a set to return false")
+	}
+	if d.Union(0, 6) {
+		t.Error("Expected union with an invalid element to return false")
+	}
+	if !d.Connected(0, 3) || d.Connected(0, 4) {
+		t.Error("Connected returned an unexpected result")
+	}
+	if d.SetSize(2) != 4 || d.SetSize(5) != 1 {
+		t.Errorf("Expected set sizes 4 and 1, got %d and %d", d.SetSize(2), d.SetSize(5))
+	}
+	if d.SetCount() != 3 {
+		t.Errorf("Expected 3 sets, got %d", d.SetCount())
+	}
+	groups := d.Groups()
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+	if len(groups) != 3 || len(groups[0]) != 4 || groups[1][0] != 4 || groups[2][0] != 5 {
+		t.Errorf("Unexpected groups %v", groups)
+	}
+	if x := d.Add(); x != 6 || d.SetCount() != 4 {
+		t.Errorf("Expected Add to create element 6, got %d", x)
+	}
+	if d.Rollback(0) {
+		t.Error("Expected Rollback to fail without rollback mode")
+	}
+}
+
+func TestDenseUnionFindRollback(t *testing.T) {
+	d := unionfind.NewDenseRollback(5)
+	d.Union(0, 1)
+	snapshot := d.Snapshot()
+	d.Union(1, 2)
+	d.Add()
+	d.Union(5, 3)
+	if d.SetCount() != 3 || d.Length() != 6 {
+		t.Fatalf("Expected 3 sets of 6 elements, got %d sets of %d", d.SetCount(), d.Length())
+	}
+	if !d.Rollback(snapshot) {
+		t.Fatal("Expected Rollback to succeed")
+	}
+	if d.Length() != 5 || d.SetCount() != 4 {
+		t.Errorf("Expected 4 sets of 5 elements, got %d sets of %d", d.SetCount(), d.Length())
+	}
+	if !d.Connected(0, 1) || d.Connected(1, 2) || d.Connected(3, 5) {
+		t.Error("Expected state to match the snapshot")
+	}
+	if d.Rollback(snapshot + 1) {
+		t.Error("Expected Rollback to a future snapshot to fail")
+	}
+}
+
+func TestUnionFind(t *testing.T) {
+	u := unionfind.New[string]()
+	u.Union("a", "b")
+	u.Union("c", "d")
+	u.Add("e")
+	if u.Add("a") {
+		t.Error("Expected Add of an existing element to return false")
+	}
+	if !u.Connected("a", "b") || u.Connected("a", "c") || u.Connected("a", "missing") {
+		t.Error("Connected returned an unexpected result")
+	}
+	if root, ok := u.Find("b"); !ok || (root != "a" && root != "b") {
+		t.Errorf("Unexpected representative %q", root)
+	}
+	if _, ok := u.Find("missing"); ok {
+		t.Error("Expected Find of a missing element to return false")
+	}
+	if u.SetCount() != 3 || u.Length() != 5 || u.SetSize("d") != 2 {
+		t.Errorf("Unexpected counts: %d sets, %d elements", u.SetCount(), u.Length())
+	}
+}
+
+func TestUnionFindRollback(t *testing.T) {
+	u := unionfind.NewRollback[string]()
+	u.Union("a", "b")
+	snapshot := u.Snapshot()
+	u.Union("b", "c")
+	u.Union("x", "y")
+	u.Rollback(snapshot)
+	if u.Contains("c") || u.Contains("x") || !u.Contains("a") {
+		t.Error("Expected elements added after the snapshot to be removed")
+	}
+	if u.SetCount() != 1 || u.Length() != 2 {
+		t.Errorf("Expected 1 set of 2 elements, got %d sets of %d", u.SetCount(), u.Length())
+	}
+	u.Union("c", "a")
+	if u.SetSize("b") != 3 {
+		t.Errorf("Expected readded element to join the set, got size %d", u.SetSize("b"))
+	}
+}
+
+func TestUnionFindRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	n := 200
+	d := unionfind.NewDense(n)
+	label := make([]int, n)
+	for i := range label {
+		label[i] = i
+	}
+	for step := 0; step < 300; step++ {
+		a, b := r.Intn(n), r.Intn(n)
+		d.Union(a, b)
+		from, to := label[b], label[a]
+		for i := range label {
+			if label[i] == from {
+				label[i] = to
+			}
+		}
+		x, y := r.Intn(n), r.Intn(n)
+		if d.Connected(x, y) != (label[x] == label[y]) {
+			t.Fatalf("Connected(%d, %d) disagreed with reference", x, y)
+		}
+	}
+}
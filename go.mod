@@ -2,7 +2,9 @@ module github.com/mmygods/gods
 
 go 1.21.3
 
+require golang.org/x/tools v0.24.0
+
 require (
 	golang.org/x/mod v0.20.0 // indirect
-	golang.org/x/tools v0.24.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
 )